@@ -1,19 +1,33 @@
 // Package assert implements assertions for assumed behavior
 package assert
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/thatguystone/cog/callstack"
+)
 
 // True ensures that the condition is true
 func True(v bool) {
 	if !v {
-		panic("assert error")
+		msg := "assert error"
+		if args := callstack.CallArgs(0); len(args) == 1 {
+			msg += ": expected true: " + args[0]
+		}
+
+		panic(msg)
 	}
 }
 
 // Equal ensures that two values are equal
 func Equal[T comparable](a, b T) {
 	if a != b {
-		panic(fmt.Errorf("%v != %v", a, b))
+		err := fmt.Errorf("%v != %v", a, b)
+		if args := callstack.CallArgs(0); len(args) == 2 {
+			err = fmt.Errorf("%s != %s: %w", args[0], args[1], err)
+		}
+
+		panic(err)
 	}
 }
 
@@ -2,6 +2,7 @@ package assert
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/thatguystone/cog/check"
@@ -15,6 +16,11 @@ func TestTrue(t *testing.T) {
 	check.Panics(t, func() {
 		True(false)
 	})
+
+	n := 1
+	check.PanicsWith(t, "assert error: expected true: n > 1", func() {
+		True(n > 1)
+	})
 }
 
 func TestEqual(t *testing.T) {
@@ -25,6 +31,13 @@ func TestEqual(t *testing.T) {
 	check.Panics(t, func() {
 		Equal(1, 2)
 	})
+
+	n := 1
+	defer func() {
+		check.Equal(t, fmt.Sprint(recover()), "n != 2: 1 != 2")
+	}()
+
+	Equal(n, 2)
 }
 
 func TestNil(t *testing.T) {
@@ -1,19 +1,20 @@
-package callstack
+package callstack_test
 
 import (
 	"strings"
 	"testing"
 
+	"github.com/thatguystone/cog/callstack"
 	"github.com/thatguystone/cog/check"
 )
 
 const (
-	pkgPath  = "github.com/thatguystone/cog/callstack"
+	pkgPath  = "github.com/thatguystone/cog/callstack_test"
 	fileName = "frame_test.go"
 )
 
 func TestSelfFunc(t *testing.T) {
-	fr := Self().Frame()
+	fr := callstack.Self().Frame()
 
 	const funcName = "TestSelfFunc"
 	check.NotEqual(t, fr.PC(), uintptr(0))
@@ -39,7 +40,7 @@ func TestSelfMethod(t *testing.T) {
 }
 
 func TestPCZero(t *testing.T) {
-	var pc PC
+	var pc callstack.PC
 	fr := pc.Frame()
 	check.Equal(t, fr.PkgPath(), "???")
 	check.Equal(t, fr.Func(), "???")
@@ -50,14 +51,14 @@ func TestPCZero(t *testing.T) {
 }
 
 func TestFrameString(t *testing.T) {
-	str := Self().Frame().String()
+	str := callstack.Self().Frame().String()
 	check.True(t, strings.Contains(str, fileName))
 }
 
 type testSelf struct{}
 
-func (testSelf) getPC() PC {
-	return Self()
+func (testSelf) getPC() callstack.PC {
+	return callstack.Self()
 }
 
 func BenchmarkSelf(b *testing.B) {
@@ -65,7 +66,7 @@ func BenchmarkSelf(b *testing.B) {
 		b.ResetTimer()
 
 		for range b.N {
-			Self()
+			callstack.Self()
 		}
 
 		return nil
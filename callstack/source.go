@@ -0,0 +1,175 @@
+package callstack
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"sync"
+)
+
+// CallArgs gets the source expressions of the arguments passed to the
+// function skip frames above the caller, eg. CallArgs(0) called from inside
+// `func F(a, b int)` that was called as `F(x, y+1)` returns ["x", "y+1"].
+//
+// It returns nil if the source isn't available or if the call can't be found
+// unambiguously.
+func CallArgs(skip int) []string {
+	var (
+		callee = Caller(skip + 1).Frame()
+		site   = Caller(skip + 2).Frame()
+	)
+
+	src := parseSource(site.File())
+	if src == nil {
+		return nil
+	}
+
+	call := src.findCall(site.Line(), calleeName(callee))
+	if call == nil {
+		return nil
+	}
+
+	args := make([]string, len(call.Args))
+	for i, arg := range call.Args {
+		args[i] = src.text(arg)
+	}
+
+	return args
+}
+
+// calleeName gets the bare name a function is called by, stripping receivers
+// and type parameters
+func calleeName(frame Frame) string {
+	name := frame.FuncName()
+	name = strings.TrimSuffix(name, "[...]")
+
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name
+}
+
+type sourceFile struct {
+	fset *token.FileSet
+	file *ast.File
+	src  []byte
+}
+
+var sources struct {
+	mtx   sync.Mutex
+	files map[string]*sourceFile
+}
+
+// parseSource parses the file at path, caching the result. Failures are
+// cached as nil.
+func parseSource(path string) *sourceFile {
+	sources.mtx.Lock()
+	defer sources.mtx.Unlock()
+
+	sf, ok := sources.files[path]
+	if ok {
+		return sf
+	}
+
+	if sources.files == nil {
+		sources.files = map[string]*sourceFile{}
+	}
+
+	src, err := os.ReadFile(path)
+	if err == nil {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+		if err == nil {
+			sf = &sourceFile{
+				fset: fset,
+				file: file,
+				src:  src,
+			}
+		}
+	}
+
+	sources.files[path] = sf
+	return sf
+}
+
+// findCall finds the innermost call to a function with the given name that
+// spans line. If there are several such calls, they must all be written
+// identically, otherwise there's no way to tell them apart.
+func (sf *sourceFile) findCall(line int, name string) *ast.CallExpr {
+	var (
+		found *ast.CallExpr
+		text  string
+		dup   bool
+	)
+
+	ast.Inspect(sf.file, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+
+		var (
+			start = sf.fset.Position(n.Pos()).Line
+			end   = sf.fset.Position(n.End()).Line
+		)
+
+		if line < start || line > end {
+			return false
+		}
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok || funcName(call.Fun) != name {
+			return true
+		}
+
+		switch {
+		case found == nil || sf.contains(found, call):
+			found = call
+			text = sf.text(call)
+			dup = false
+
+		case !sf.contains(call, found) && sf.text(call) != text:
+			dup = true
+		}
+
+		return true
+	})
+
+	if dup {
+		return nil
+	}
+
+	return found
+}
+
+func (sf *sourceFile) contains(outer, inner ast.Node) bool {
+	return outer.Pos() <= inner.Pos() && inner.End() <= outer.End()
+}
+
+func (sf *sourceFile) text(n ast.Node) string {
+	var (
+		start = sf.fset.Position(n.Pos()).Offset
+		end   = sf.fset.Position(n.End()).Offset
+	)
+
+	return string(sf.src[start:end])
+}
+
+func funcName(fun ast.Expr) string {
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		return fun.Name
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	case *ast.IndexExpr:
+		return funcName(fun.X)
+	case *ast.IndexListExpr:
+		return funcName(fun.X)
+	case *ast.ParenExpr:
+		return funcName(fun.X)
+	default:
+		return ""
+	}
+}
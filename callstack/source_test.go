@@ -0,0 +1,66 @@
+package callstack_test
+
+import (
+	"testing"
+
+	"github.com/thatguystone/cog/callstack"
+	"github.com/thatguystone/cog/check"
+)
+
+func callArgs(...any) []string {
+	return callstack.CallArgs(0)
+}
+
+func callArgsGeneric[T any](T, T) []string {
+	return callstack.CallArgs(0)
+}
+
+type callArgser struct{}
+
+func (callArgser) args(...any) []string {
+	return callstack.CallArgs(0)
+}
+
+func TestCallArgs(t *testing.T) {
+	var (
+		a = 1
+		s = struct{ B int }{}
+	)
+
+	check.Equal(t, callArgs(a, s.B+1, "str"), []string{"a", "s.B+1", `"str"`})
+	check.Equal(t, callArgs(), []string{})
+
+	t.Run("MultiLine", func(t *testing.T) {
+		args := callArgs(
+			a,
+			s.B,
+		)
+
+		check.Equal(t, args, []string{"a", "s.B"})
+	})
+
+	t.Run("Generic", func(t *testing.T) {
+		check.Equal(t, callArgsGeneric(a, 2), []string{"a", "2"})
+		check.Equal(t, callArgsGeneric[int](a, 2), []string{"a", "2"})
+	})
+
+	t.Run("Method", func(t *testing.T) {
+		check.Equal(t, callArgser{}.args(a), []string{"a"})
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		args := append(callArgs(a), callArgs(s)...)
+		check.Equal(t, args, []string(nil))
+
+		var all [][]string
+		for range 2 {
+			all = append(all, callArgs(a))
+		}
+		check.Equal(t, all, [][]string{{"a"}, {"a"}})
+	})
+
+	t.Run("Closure", func(t *testing.T) {
+		fn := func() []string { return callstack.CallArgs(0) }
+		check.Equal(t, fn(), []string(nil))
+	})
+}
@@ -1,10 +1,11 @@
-package callstack
+package callstack_test
 
 import (
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/thatguystone/cog/callstack"
 	"github.com/thatguystone/cog/check"
 )
 
@@ -23,21 +24,21 @@ func recurse[T any](n int, cb func() T) T {
 func TestGet(t *testing.T) {
 	funcName := pkgName + ".TestGet"
 
-	st := Get()
+	st := callstack.Get()
 	check.Equal(t, st.Slice()[0].Func(), funcName)
 	check.True(t, strings.Contains(st.String(), funcName))
 
 	const depth = 129
 	expectDepth := len(st.Slice()) + depth
 
-	frames := recurse(depth, Get).Slice()
+	frames := recurse(depth, callstack.Get).Slice()
 	check.Equalf(t, len(frames), expectDepth, "%s", st)
 	check.Equal(t, frames[depth].Func(), funcName)
 }
 
 func TestStackIters(t *testing.T) {
 	recurse(10, func() any {
-		for _ = range Get().All() {
+		for _ = range callstack.Get().All() {
 			break
 		}
 
@@ -46,7 +47,7 @@ func TestStackIters(t *testing.T) {
 }
 
 func TestStackString(t *testing.T) {
-	var stack Stack
+	var stack callstack.Stack
 	check.True(t, stack.IsZero())
 	check.Equal(t, stack.String(), "")
 }
@@ -56,7 +57,7 @@ func BenchmarkGet(b *testing.B) {
 		b.ResetTimer()
 
 		for range b.N {
-			Get()
+			callstack.Get()
 		}
 
 		return nil
@@ -0,0 +1,212 @@
+package check
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/thatguystone/cog/textwrap"
+)
+
+// Receives checks that a value is received from ch within timeout, returning
+// it, or the zero value if nothing was received
+func Receives[T any](t Error, ch <-chan T, timeout time.Duration) T {
+	v, msg, ok := checkReceives(ch, timeout)
+	if !ok {
+		t.Helper()
+		msg = Report(t, "Receives", msg)
+		t.Error("\n" + msg)
+	}
+
+	return v
+}
+
+// MustReceive is like [Receives], but fails the test immediately
+func MustReceive[T any](t Fatal, ch <-chan T, timeout time.Duration) T {
+	v, msg, ok := checkReceives(ch, timeout)
+	if !ok {
+		t.Helper()
+		msg = Report(t, "MustReceive", msg)
+		t.Fatal("\n" + msg)
+	}
+
+	return v
+}
+
+// ReceivesValue checks that want is received from ch within timeout
+func ReceivesValue[T any](t Error, ch <-chan T, want T, timeout time.Duration) bool {
+	if msg, ok := checkReceivesValue(ch, want, timeout); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, NoExpr, want, NoExpr)
+		msg = Report(t, "ReceivesValue", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// NotReceives checks that nothing is received from ch, and that it isn't
+// closed, for dur
+func NotReceives[T any](t Error, ch <-chan T, dur time.Duration) bool {
+	if msg, ok := checkNotReceives(ch, dur); !ok {
+		t.Helper()
+		msg = Report(t, "NotReceives", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Closed checks that ch is closed within timeout, without anything being
+// received from it first
+func Closed[T any](t Error, ch <-chan T, timeout time.Duration) bool {
+	if msg, ok := checkClosed(ch, timeout); !ok {
+		t.Helper()
+		msg = Report(t, "Closed", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+func checkReceives[T any](ch <-chan T, timeout time.Duration) (v T, msg string, ok bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			return v, "Expected to receive from " + chanDesc(ch) + ", but it's closed", false
+		}
+
+		return v, "", true
+
+	case <-timer.C:
+		return v, chanTimeoutMsg("receive from", ch, timeout), false
+	}
+}
+
+func checkReceivesValue[T any](ch <-chan T, want T, timeout time.Duration) (string, bool) {
+	got, msg, ok := checkReceives(ch, timeout)
+	if !ok {
+		return msg, false
+	}
+
+	if deepEqual(got, want) {
+		return "", true
+	}
+
+	return "Received an unexpected value from " + chanDesc(ch) + ":\n" +
+		textwrap.Indent(equalMsg(got, want), dumpIndent), false
+}
+
+func checkNotReceives[T any](ch <-chan T, dur time.Duration) (string, bool) {
+	timer := time.NewTimer(dur)
+	defer timer.Stop()
+
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			return "Expected nothing from " + chanDesc(ch) + ", but it's closed", false
+		}
+
+		return "Expected nothing from " + chanDesc(ch) + ", but received:\n" +
+			dump(v, 1), false
+
+	case <-timer.C:
+		return "", true
+	}
+}
+
+func checkClosed[T any](ch <-chan T, timeout time.Duration) (string, bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			return "", true
+		}
+
+		return "Expected " + chanDesc(ch) + " to be closed, but received:\n" +
+			dump(v, 1), false
+
+	case <-timer.C:
+		return chanTimeoutMsg("close of", ch, timeout), false
+	}
+}
+
+func chanDesc[T any](ch <-chan T) string {
+	if ch == nil {
+		return typeName(reflect.TypeOf(ch)) + " (nil)"
+	}
+
+	return fmt.Sprintf(
+		"%s (len %d, cap %d)",
+		typeName(reflect.TypeOf(ch)),
+		len(ch),
+		cap(ch),
+	)
+}
+
+func chanTimeoutMsg[T any](op string, ch <-chan T, timeout time.Duration) string {
+	msg := fmt.Sprintf("Timed out after %s waiting for %s %s", timeout, op, chanDesc(ch))
+
+	blocked := blockedGoroutines()
+	if len(blocked) == 0 {
+		return msg + "\nNo goroutines are blocked on channel operations"
+	}
+
+	return msg + "\nGoroutines blocked on channel operations:\n" +
+		textwrap.Indent(strings.Join(blocked, "\n\n"), dumpIndent)
+}
+
+// blockedGoroutines gets the stacks of goroutines blocked on channel
+// operations, except those belonging to package testing, which are always
+// blocked waiting for tests to finish
+func blockedGoroutines() []string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+
+		buf = make([]byte, len(buf)*2)
+	}
+
+	var blocked []string
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		header, frames, _ := strings.Cut(string(stack), "\n")
+
+		isBlocked := strings.Contains(header, "[chan send") ||
+			strings.Contains(header, "[chan receive") ||
+			strings.Contains(header, "[select")
+		if isBlocked && !inTesting(frames) {
+			blocked = append(blocked, strings.TrimSpace(string(stack)))
+		}
+	}
+
+	return blocked
+}
+
+// inTesting determines if the first non-runtime frame of a goroutine's stack
+// is in package testing
+func inTesting(frames string) bool {
+	for _, line := range strings.Split(frames, "\n") {
+		if strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "runtime.") {
+			continue
+		}
+
+		return strings.HasPrefix(line, "testing.")
+	}
+
+	return false
+}
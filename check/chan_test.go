@@ -0,0 +1,102 @@
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChanChecks(t *testing.T) {
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+
+	Equal(t, Receives(t, ch, time.Second), 1)
+	Equal(t, MustReceive(t, ch, time.Second), 2)
+
+	ch <- 3
+	ReceivesValue(t, ch, 3, time.Second)
+	NotReceives(t, ch, time.Millisecond)
+
+	close(ch)
+	Closed(t, ch, time.Second)
+}
+
+func TestCheckReceives(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 1
+
+	v, msg, ok := checkReceives(ch, time.Second)
+	testCheck(msg, ok)(t, true)
+	Equal(t, v, 1)
+
+	_, msg, ok = checkReceives(ch, time.Millisecond)
+	testCheck(msg, ok)(t, false)
+	Contains(t, msg, "Timed out after 1ms waiting for receive from <-chan int (len 0, cap 1)")
+
+	close(ch)
+	_, msg, ok = checkReceives(ch, time.Second)
+	testCheck(msg, ok)(t, false)
+	Contains(t, msg, "but it's closed")
+
+	_, msg, ok = checkReceives[int](nil, time.Millisecond)
+	testCheck(msg, ok)(t, false)
+	Contains(t, msg, "<-chan int (nil)")
+}
+
+func TestCheckReceivesValue(t *testing.T) {
+	ch := make(chan []int, 2)
+	ch <- []int{1}
+	ch <- []int{2}
+
+	testCheck(checkReceivesValue(ch, []int{1}, time.Second))(t, true)
+	testCheck(checkReceivesValue(ch, []int{1}, time.Second))(t, false)
+	testCheck(checkReceivesValue(ch, []int{1}, time.Millisecond))(t, false)
+}
+
+func TestCheckNotReceives(t *testing.T) {
+	ch := make(chan int, 1)
+	testCheck(checkNotReceives(ch, time.Millisecond))(t, true)
+
+	ch <- 1
+	testCheck(checkNotReceives(ch, time.Second))(t, false)
+
+	close(ch)
+	testCheck(checkNotReceives(ch, time.Second))(t, false)
+}
+
+func TestCheckClosed(t *testing.T) {
+	ch := make(chan int, 1)
+	testCheck(checkClosed(ch, time.Millisecond))(t, false)
+
+	ch <- 1
+	testCheck(checkClosed(ch, time.Second))(t, false)
+
+	close(ch)
+	testCheck(checkClosed(ch, time.Second))(t, true)
+}
+
+func blockedSender(ch chan int) {
+	ch <- 1
+}
+
+func TestChanTimeoutBlocked(t *testing.T) {
+	msg := chanTimeoutMsg[int]("receive from", nil, time.Millisecond)
+	Contains(t, msg, "No goroutines are blocked on channel operations")
+
+	ch := make(chan int)
+	go blockedSender(ch)
+
+	// Wait for the sender to block
+	EventuallyTrue(t, 1000, func(int) bool {
+		time.Sleep(time.Millisecond)
+		msg = chanTimeoutMsg[int]("receive from", nil, time.Millisecond)
+		return strings.Contains(msg, "blockedSender")
+	})
+
+	Contains(t, msg, "Goroutines blocked on channel operations:\n"+dumpIndent+"goroutine ")
+	Contains(t, msg, "[chan send]:\n")
+	NotContains(t, msg, "testing.(*T).Run")
+
+	<-ch
+}
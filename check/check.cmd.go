@@ -5,85 +5,17 @@
 package main
 
 import (
-	"text/template"
-
-	"github.com/thatguystone/cog/assert"
 	"github.com/thatguystone/cog/generate"
+	"github.com/thatguystone/cog/generate/checkgen"
 )
 
-func newTemplate(tmpl string) *template.Template {
-	return assert.Must(template.New("assert").Parse(tmpl))
-}
-
 func main() {
-	tmpls := []*template.Template{
-		newTemplate(`
-			// {{ .Doc }}
-			func {{ .Name }}(t Error, {{ .Args }}) bool {
-				if msg, ok := {{ .Check }}; !ok {
-					t.Helper()
-					t.Error("\n" + msg)
-					return false
-				}
-
-				return true
-			}
-		`),
-		newTemplate(`
-			// {{ .Doc }}
-			func {{ .Name }}f(t Error, {{ .Args }}, format string, args ...any) bool {
-				if msg, ok := {{ .Check }}; !ok {
-					t.Helper()
-					t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
-					return false
-				}
-
-				return true
-			}
-		`),
-		newTemplate(`
-			// {{ .Doc }}
-			func Must{{ or .Must .Name  }}(t Fatal, {{ .Args }}) {
-				if msg, ok := {{ .Check }}; !ok {
-					t.Helper()
-					t.Fatal("\n" + msg)
-				}
-			}
-		`),
-		newTemplate(`
-			// {{ .Doc }}
-			func Must{{ or .Must .Name }}f(t Fatal, {{ .Args }}, format string, args ...any) {
-				if msg, ok := {{ .Check }}; !ok {
-					t.Helper()
-					t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
-				}
-			}
-		`),
-	}
-
 	b := generate.New()
-	b.WriteString("\n//gocovr:skip-file\n")
-	b.WriteString("\nimport()\n")
-
-	for _, fn := range funcs {
-		for _, tmpl := range tmpls {
-			err := tmpl.Execute(b, fn)
-			assert.Nil(err)
-		}
-	}
-
+	checkgen.Generate(b, funcs...)
 	b.WriteFile()
 }
 
-type Func struct {
-	Name  string
-	Must  string
-	Args  string
-	Check string
-	Doc   string
-}
-
-var funcs = []Func{
+var funcs = []checkgen.Func{
 	{
 		Name:  "True",
 		Args:  "cond bool",
@@ -100,12 +32,16 @@ var funcs = []Func{
 		Name:  "Equal",
 		Args:  "g, e any",
 		Check: "checkEqual(g, e)",
-		Doc:   "Check that two things are equal; e is the expected value, g is what was got.",
+		Got:   "g",
+		Want:  "e",
+		Doc:   "Check that two things are equal; e is the expected value, g is what was got. Values with an `Equal(T) bool` method, or an equality func registered with [RegisterEqual], are compared with it.",
 	},
 	{
 		Name:  "NotEqual",
 		Args:  "g, e any",
 		Check: "checkNotEqual(g, e)",
+		Got:   "g",
+		Want:  "e",
 		Doc:   "Check that two things are not equal; e is the expected value, g is what was got.",
 	},
 	{
@@ -172,6 +108,70 @@ var funcs = []Func{
 		Check: "checkNotContains(iter, v)",
 		Doc:   "Check that iter does not contain value v. Iter must be one of: map, slice, array, or string",
 	},
+	{
+		Name:  "Subset",
+		Must:  "BeSubset",
+		Args:  "g, e any",
+		Check: "checkSubset(g, e)",
+		Doc:   "Check that every element of g is in e; e is the expected value, g is what was got. Slices and arrays are compared as multisets, so duplicates must be matched by as many elements; maps must match by key and value.",
+	},
+	{
+		Name:  "Superset",
+		Must:  "BeSuperset",
+		Args:  "g, e any",
+		Check: "checkSuperset(g, e)",
+		Doc:   "Check that every element of e is in g; e is the expected value, g is what was got. Slices and arrays are compared as multisets, so duplicates must be matched by as many elements; maps must match by key and value.",
+	},
+	{
+		Name:  "Same",
+		Args:  "g, e any",
+		Check: "checkSame(g, e)",
+		Doc:   "Check that two pointers, chans, or maps are identical, ie. they point to the same thing; e is the expected value, g is what was got.",
+	},
+	{
+		Name:  "NotSame",
+		Args:  "g, e any",
+		Check: "checkNotSame(g, e)",
+		Doc:   "Check that two pointers, chans, or maps are not identical, ie. they point to different things; e is the expected value, g is what was got.",
+	},
+	{
+		Name:  "Text",
+		Args:  "g, e string",
+		Check: "checkText(g, e)",
+		// The diff already shows both texts in full
+		NoExprs: "g, e",
+		Doc:     "Check that two texts are equal, diffing them line by line; e is the expected value, g is what was got. e may be written as an indented raw string literal: it's dedented and a leading newline is removed. Line endings and trailing whitespace are ignored.",
+	},
+	{
+		Name:  "TextDedent",
+		Args:  "g, e string",
+		Check: "checkTextDedent(g, e)",
+		// The diff already shows both texts in full
+		NoExprs: "g, e",
+		Doc:     "Check that two texts are equal, like [Text], but dedent g too.",
+	},
+	{
+		Name:  "TimeEqual",
+		Args:  "g, e time.Time",
+		Check: "checkTimeEqual(g, e)",
+		Doc:   "Check that two times are the same instant, using [time.Time.Equal], so monotonic clock readings and locations don't matter; e is the expected value, g is what was got.",
+		Got:   "g",
+		Want:  "e",
+	},
+	{
+		Name:  "WithinDuration",
+		Args:  "g, e time.Time, d time.Duration",
+		Check: "checkWithinDuration(g, e, d)",
+		Doc:   "Check that two times are at most d apart; e is the expected value, g is what was got.",
+		Got:   "g",
+		Want:  "e",
+	},
+	{
+		Name:  "TimeBetween",
+		Args:  "g, start, end time.Time",
+		Check: "checkTimeBetween(g, start, end)",
+		Doc:   "Check that g is between start and end, inclusive.",
+	},
 	{
 		Name:  "Panics",
 		Must:  "Panic",
@@ -205,4 +205,41 @@ var funcs = []Func{
 		Check: "checkEventuallyNil(numTries, fn)",
 		Doc:   "Poll the given function, a max of numTries times, until it doesn't return an error. This is mainly a helper used to exhaust error pathways.",
 	},
+	{
+		Name:  "AllocsAtMost",
+		Args:  "n float64, fn func()",
+		Check: "checkAllocsAtMost(n, fn)",
+		Doc:   "Check that fn makes at most n allocations per run, on average, as measured by [testing.AllocsPerRun].",
+	},
+	{
+		Name:  "FasterThan",
+		Args:  "budget time.Duration, fn func()",
+		Check: "checkFasterThan(budget, fn)",
+		Doc:   "Check that fn runs within budget. fn is run a few times to warm up, then the median of several timed runs is compared to budget.",
+	},
+	{
+		Name:  "FileExists",
+		Args:  "path string",
+		Check: "checkFileExists(path)",
+		Doc:   "Check that path exists and is a file.",
+	},
+	{
+		Name:  "DirExists",
+		Args:  "path string",
+		Check: "checkDirExists(path)",
+		Doc:   "Check that path exists and is a directory.",
+	},
+	{
+		Name:  "FileContent",
+		Must:  "HaveFileContent",
+		Args:  "path, want string",
+		Check: "checkFileContent(path, want)",
+		Doc:   "Check that the file at path contains exactly want.",
+	},
+	{
+		Name:  "TreeEqual",
+		Args:  "g, e any",
+		Check: "checkTreeEqual(g, e)",
+		Doc:   "Check that two trees of files are equal. Each tree is either a directory path or an [fs.FS], eg. from [Txtar]; only regular files are compared.",
+	},
 }
@@ -7,8 +7,8 @@ import (
 	"runtime/debug"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
-	"github.com/peter-evans/patience"
 	"github.com/thatguystone/cog/textwrap"
 )
 
@@ -40,63 +40,90 @@ func checkFalse(cond bool) (string, bool) {
 
 func equalMsg(g, e any) string {
 	var (
-		gs = dump(g, 0)
-		gl = strings.Split(gs, "\n")
-		es = dump(e, 0)
-		el = strings.Split(es, "\n")
+		st     = newDiffState()
+		gd, ed = st.dumpPair(g, e)
+		b      = new(strings.Builder)
 	)
 
-	if len(gl) == 1 && len(el) == 1 {
-		return fmt.Sprintf(""+
+	if len(gd.lines) == 1 && len(ed.lines) == 1 {
+		gl, el := clipDiffering(gd.lines[0], ed.lines[0])
+		fmt.Fprintf(b, ""+
 			"Expected: %s\n"+
 			"       == %s",
-			gs,
-			es,
+			gl,
+			el,
 		)
+
+		if gd.lines[0] == ed.lines[0] {
+			b.WriteString("\n")
+			writeIdenticalNote(b)
+		}
+
+		return b.String()
 	}
 
-	var (
-		b     = new(strings.Builder)
-		diffs = patience.Diff(gl, el)
-	)
+	b.WriteString("Expected values to be equal:\n")
+	writeValueDiff(b, g, e, dumpIndent, st)
 
-	const (
-		prefixLen = 2
-		prelude   = "Expected values to be equal:\n"
-	)
+	return strings.TrimSuffix(b.String(), "\n")
+}
 
-	n := len(prelude) + (len(dumpIndent)+prefixLen+1)*len(diffs)
-	for _, diff := range diffs {
-		n += len(diff.Text)
+// clipDiffering shortens two lines that are longer than [Config.MaxLen] to
+// the part around where they first differ
+func clipDiffering(g, e string) (string, string) {
+	maxLen := Settings.MaxLen
+	if fullDump || maxLen <= 0 || max(len(g), len(e)) <= maxLen {
+		return g, e
 	}
 
-	b.Grow(n)
-	b.WriteString(prelude)
+	at := 0
+	for at < len(g) && at < len(e) && g[at] == e[at] {
+		at++
+	}
 
-	for i, diff := range diffs {
-		if i > 0 {
-			b.WriteByte('\n')
-		}
+	return clipAround(g, at, maxLen), clipAround(e, at, maxLen)
+}
 
-		b.WriteString(dumpIndent)
+// clipAround cuts line down to about width bytes around offset at, marking
+// what was cut
+func clipAround(line string, at, width int) string {
+	if len(line) <= width {
+		return line
+	}
 
-		switch diff.Type {
-		case patience.Delete:
-			b.WriteString("- ")
-		case patience.Insert:
-			b.WriteString("+ ")
-		default:
-			b.WriteString("  ")
-		}
+	start := max(0, at-(width/2))
+	end := min(len(line), start+width)
+	start = max(0, end-width)
+
+	// Don't split a rune in half
+	for start > 0 && !utf8.RuneStart(line[start]) {
+		start--
+	}
+
+	for end < len(line) && !utf8.RuneStart(line[end]) {
+		end++
+	}
+
+	clipped := line[start:end]
+	if start > 0 {
+		clipped = "/* ... */ " + clipped
+	}
 
-		b.WriteString(diff.Text)
+	if end < len(line) {
+		clipped += " /* ... */"
 	}
 
-	return b.String()
+	return clipped
+}
+
+// Diff describes how g differs from e, the same way [Equal] does when it
+// fails. It's meant for building custom checks.
+func Diff(g, e any) string {
+	return equalMsg(g, e)
 }
 
 func checkEqual(g, e any) (string, bool) {
-	if reflect.DeepEqual(g, e) {
+	if deepEqual(g, e) {
 		return "", true
 	}
 
@@ -104,7 +131,7 @@ func checkEqual(g, e any) (string, bool) {
 }
 
 func checkNotEqual(g, e any) (string, bool) {
-	if reflect.DeepEqual(g, e) {
+	if deepEqual(g, e) {
 		return "Expected values to differ:\n" + dump(g, 1), false
 	}
 
@@ -250,7 +277,7 @@ func contains(iter, v any) (msg, what string, ok bool) {
 		what = "value"
 
 		for mi := rv.MapRange(); mi.Next(); {
-			if reflect.DeepEqual(mi.Value().Interface(), v) {
+			if deepEqual(mi.Value().Interface(), v) {
 				ok = true
 				return
 			}
@@ -262,7 +289,7 @@ func contains(iter, v any) (msg, what string, ok bool) {
 		what = "value"
 
 		for i := range rv.Len() {
-			if reflect.DeepEqual(rv.Index(i).Interface(), v) {
+			if deepEqual(rv.Index(i).Interface(), v) {
 				ok = true
 				return
 			}
@@ -350,7 +377,7 @@ func checkPanicsWith(recovers any, fn func()) (msg string, ok bool) {
 			return
 		}
 
-		if !reflect.DeepEqual(r, recovers) {
+		if !deepEqual(r, recovers) {
 			msg = equalMsg(r, recovers) +
 				"\n" +
 				"\n" +
@@ -4,12 +4,17 @@ package check
 
 //gocovr:skip-file
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Check that the given bool is true.
 func True(t Error, cond bool) bool {
 	if msg, ok := checkTrue(cond); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, cond)
+		msg = Report(t, "True", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -21,7 +26,9 @@ func True(t Error, cond bool) bool {
 func Truef(t Error, cond bool, format string, args ...any) bool {
 	if msg, ok := checkTrue(cond); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, cond)
+		msg = Report(t, "Truef", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -32,6 +39,8 @@ func Truef(t Error, cond bool, format string, args ...any) bool {
 func MustTrue(t Fatal, cond bool) {
 	if msg, ok := checkTrue(cond); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, cond)
+		msg = Report(t, "MustTrue", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -40,7 +49,9 @@ func MustTrue(t Fatal, cond bool) {
 func MustTruef(t Fatal, cond bool, format string, args ...any) {
 	if msg, ok := checkTrue(cond); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, cond)
+		msg = Report(t, "MustTruef", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -48,6 +59,8 @@ func MustTruef(t Fatal, cond bool, format string, args ...any) {
 func False(t Error, cond bool) bool {
 	if msg, ok := checkFalse(cond); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, cond)
+		msg = Report(t, "False", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -59,7 +72,9 @@ func False(t Error, cond bool) bool {
 func Falsef(t Error, cond bool, format string, args ...any) bool {
 	if msg, ok := checkFalse(cond); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, cond)
+		msg = Report(t, "Falsef", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -70,6 +85,8 @@ func Falsef(t Error, cond bool, format string, args ...any) bool {
 func MustFalse(t Fatal, cond bool) {
 	if msg, ok := checkFalse(cond); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, cond)
+		msg = Report(t, "MustFalse", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -78,14 +95,18 @@ func MustFalse(t Fatal, cond bool) {
 func MustFalsef(t Fatal, cond bool, format string, args ...any) {
 	if msg, ok := checkFalse(cond); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, cond)
+		msg = Report(t, "MustFalsef", msg)
+		t.Fatal(msg)
 	}
 }
 
-// Check that two things are equal; e is the expected value, g is what was got.
+// Check that two things are equal; e is the expected value, g is what was got. Values with an `Equal(T) bool` method, or an equality func registered with [RegisterEqual], are compared with it.
 func Equal(t Error, g, e any) bool {
 	if msg, ok := checkEqual(g, e); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "Equal", msg, g, e)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -93,30 +114,36 @@ func Equal(t Error, g, e any) bool {
 	return true
 }
 
-// Check that two things are equal; e is the expected value, g is what was got.
+// Check that two things are equal; e is the expected value, g is what was got. Values with an `Equal(T) bool` method, or an equality func registered with [RegisterEqual], are compared with it.
 func Equalf(t Error, g, e any, format string, args ...any) bool {
 	if msg, ok := checkEqual(g, e); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "Equalf", msg, g, e)
+		t.Error(msg)
 		return false
 	}
 
 	return true
 }
 
-// Check that two things are equal; e is the expected value, g is what was got.
+// Check that two things are equal; e is the expected value, g is what was got. Values with an `Equal(T) bool` method, or an equality func registered with [RegisterEqual], are compared with it.
 func MustEqual(t Fatal, g, e any) {
 	if msg, ok := checkEqual(g, e); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "MustEqual", msg, g, e)
 		t.Fatal("\n" + msg)
 	}
 }
 
-// Check that two things are equal; e is the expected value, g is what was got.
+// Check that two things are equal; e is the expected value, g is what was got. Values with an `Equal(T) bool` method, or an equality func registered with [RegisterEqual], are compared with it.
 func MustEqualf(t Fatal, g, e any, format string, args ...any) {
 	if msg, ok := checkEqual(g, e); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "MustEqualf", msg, g, e)
+		t.Fatal(msg)
 	}
 }
 
@@ -124,6 +151,8 @@ func MustEqualf(t Fatal, g, e any, format string, args ...any) {
 func NotEqual(t Error, g, e any) bool {
 	if msg, ok := checkNotEqual(g, e); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "NotEqual", msg, g, e)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -135,7 +164,9 @@ func NotEqual(t Error, g, e any) bool {
 func NotEqualf(t Error, g, e any, format string, args ...any) bool {
 	if msg, ok := checkNotEqual(g, e); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "NotEqualf", msg, g, e)
+		t.Error(msg)
 		return false
 	}
 
@@ -146,6 +177,8 @@ func NotEqualf(t Error, g, e any, format string, args ...any) bool {
 func MustNotEqual(t Fatal, g, e any) {
 	if msg, ok := checkNotEqual(g, e); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "MustNotEqual", msg, g, e)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -154,7 +187,9 @@ func MustNotEqual(t Fatal, g, e any) {
 func MustNotEqualf(t Fatal, g, e any, format string, args ...any) {
 	if msg, ok := checkNotEqual(g, e); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "MustNotEqualf", msg, g, e)
+		t.Fatal(msg)
 	}
 }
 
@@ -162,6 +197,8 @@ func MustNotEqualf(t Fatal, g, e any, format string, args ...any) {
 func Nil(t Error, v any) bool {
 	if msg, ok := checkNil(v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "Nil", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -173,7 +210,9 @@ func Nil(t Error, v any) bool {
 func Nilf(t Error, v any, format string, args ...any) bool {
 	if msg, ok := checkNil(v); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, v)
+		msg = Report(t, "Nilf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -184,6 +223,8 @@ func Nilf(t Error, v any, format string, args ...any) bool {
 func MustNil(t Fatal, v any) {
 	if msg, ok := checkNil(v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "MustNil", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -192,7 +233,9 @@ func MustNil(t Fatal, v any) {
 func MustNilf(t Fatal, v any, format string, args ...any) {
 	if msg, ok := checkNil(v); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, v)
+		msg = Report(t, "MustNilf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -200,6 +243,8 @@ func MustNilf(t Fatal, v any, format string, args ...any) {
 func NotNil(t Error, v any) bool {
 	if msg, ok := checkNotNil(v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "NotNil", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -211,7 +256,9 @@ func NotNil(t Error, v any) bool {
 func NotNilf(t Error, v any, format string, args ...any) bool {
 	if msg, ok := checkNotNil(v); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, v)
+		msg = Report(t, "NotNilf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -222,6 +269,8 @@ func NotNilf(t Error, v any, format string, args ...any) bool {
 func MustNotNil(t Fatal, v any) {
 	if msg, ok := checkNotNil(v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "MustNotNil", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -230,7 +279,9 @@ func MustNotNil(t Fatal, v any) {
 func MustNotNilf(t Fatal, v any, format string, args ...any) {
 	if msg, ok := checkNotNil(v); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, v)
+		msg = Report(t, "MustNotNilf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -238,6 +289,8 @@ func MustNotNilf(t Fatal, v any, format string, args ...any) {
 func Zero(t Error, v any) bool {
 	if msg, ok := checkZero(v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "Zero", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -249,7 +302,9 @@ func Zero(t Error, v any) bool {
 func Zerof(t Error, v any, format string, args ...any) bool {
 	if msg, ok := checkZero(v); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, v)
+		msg = Report(t, "Zerof", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -260,6 +315,8 @@ func Zerof(t Error, v any, format string, args ...any) bool {
 func MustZero(t Fatal, v any) {
 	if msg, ok := checkZero(v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "MustZero", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -268,7 +325,9 @@ func MustZero(t Fatal, v any) {
 func MustZerof(t Fatal, v any, format string, args ...any) {
 	if msg, ok := checkZero(v); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, v)
+		msg = Report(t, "MustZerof", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -276,6 +335,8 @@ func MustZerof(t Fatal, v any, format string, args ...any) {
 func NotZero(t Error, v any) bool {
 	if msg, ok := checkNotZero(v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "NotZero", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -287,7 +348,9 @@ func NotZero(t Error, v any) bool {
 func NotZerof(t Error, v any, format string, args ...any) bool {
 	if msg, ok := checkNotZero(v); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, v)
+		msg = Report(t, "NotZerof", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -298,6 +361,8 @@ func NotZerof(t Error, v any, format string, args ...any) bool {
 func MustNotZero(t Fatal, v any) {
 	if msg, ok := checkNotZero(v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "MustNotZero", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -306,7 +371,9 @@ func MustNotZero(t Fatal, v any) {
 func MustNotZerof(t Fatal, v any, format string, args ...any) {
 	if msg, ok := checkNotZero(v); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, v)
+		msg = Report(t, "MustNotZerof", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -314,6 +381,8 @@ func MustNotZerof(t Fatal, v any, format string, args ...any) {
 func ErrIs(t Error, err, target error) bool {
 	if msg, ok := checkErrIs(err, target); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, err, target)
+		msg = Report(t, "ErrIs", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -325,7 +394,9 @@ func ErrIs(t Error, err, target error) bool {
 func ErrIsf(t Error, err, target error, format string, args ...any) bool {
 	if msg, ok := checkErrIs(err, target); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, err, target)
+		msg = Report(t, "ErrIsf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -336,6 +407,8 @@ func ErrIsf(t Error, err, target error, format string, args ...any) bool {
 func MustErrIs(t Fatal, err, target error) {
 	if msg, ok := checkErrIs(err, target); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, err, target)
+		msg = Report(t, "MustErrIs", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -344,7 +417,9 @@ func MustErrIs(t Fatal, err, target error) {
 func MustErrIsf(t Fatal, err, target error, format string, args ...any) {
 	if msg, ok := checkErrIs(err, target); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, err, target)
+		msg = Report(t, "MustErrIsf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -352,6 +427,8 @@ func MustErrIsf(t Fatal, err, target error, format string, args ...any) {
 func ErrAs(t Error, err error, target any) bool {
 	if msg, ok := checkErrAs(err, target); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, err, target)
+		msg = Report(t, "ErrAs", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -363,7 +440,9 @@ func ErrAs(t Error, err error, target any) bool {
 func ErrAsf(t Error, err error, target any, format string, args ...any) bool {
 	if msg, ok := checkErrAs(err, target); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, err, target)
+		msg = Report(t, "ErrAsf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -374,6 +453,8 @@ func ErrAsf(t Error, err error, target any, format string, args ...any) bool {
 func MustErrAs(t Fatal, err error, target any) {
 	if msg, ok := checkErrAs(err, target); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, err, target)
+		msg = Report(t, "MustErrAs", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -382,7 +463,9 @@ func MustErrAs(t Fatal, err error, target any) {
 func MustErrAsf(t Fatal, err error, target any, format string, args ...any) {
 	if msg, ok := checkErrAs(err, target); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, err, target)
+		msg = Report(t, "MustErrAsf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -390,6 +473,8 @@ func MustErrAsf(t Fatal, err error, target any, format string, args ...any) {
 func HasKey(t Error, m, k any) bool {
 	if msg, ok := checkHasKey(m, k); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, m, k)
+		msg = Report(t, "HasKey", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -401,7 +486,9 @@ func HasKey(t Error, m, k any) bool {
 func HasKeyf(t Error, m, k any, format string, args ...any) bool {
 	if msg, ok := checkHasKey(m, k); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, m, k)
+		msg = Report(t, "HasKeyf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -412,6 +499,8 @@ func HasKeyf(t Error, m, k any, format string, args ...any) bool {
 func MustHaveKey(t Fatal, m, k any) {
 	if msg, ok := checkHasKey(m, k); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, m, k)
+		msg = Report(t, "MustHaveKey", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -420,7 +509,9 @@ func MustHaveKey(t Fatal, m, k any) {
 func MustHaveKeyf(t Fatal, m, k any, format string, args ...any) {
 	if msg, ok := checkHasKey(m, k); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, m, k)
+		msg = Report(t, "MustHaveKeyf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -428,6 +519,8 @@ func MustHaveKeyf(t Fatal, m, k any, format string, args ...any) {
 func NotHasKey(t Error, m, k any) bool {
 	if msg, ok := checkNotHasKey(m, k); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, m, k)
+		msg = Report(t, "NotHasKey", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -439,7 +532,9 @@ func NotHasKey(t Error, m, k any) bool {
 func NotHasKeyf(t Error, m, k any, format string, args ...any) bool {
 	if msg, ok := checkNotHasKey(m, k); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, m, k)
+		msg = Report(t, "NotHasKeyf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -450,6 +545,8 @@ func NotHasKeyf(t Error, m, k any, format string, args ...any) bool {
 func MustNotHaveKey(t Fatal, m, k any) {
 	if msg, ok := checkNotHasKey(m, k); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, m, k)
+		msg = Report(t, "MustNotHaveKey", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -458,7 +555,9 @@ func MustNotHaveKey(t Fatal, m, k any) {
 func MustNotHaveKeyf(t Fatal, m, k any, format string, args ...any) {
 	if msg, ok := checkNotHasKey(m, k); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, m, k)
+		msg = Report(t, "MustNotHaveKeyf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -466,6 +565,8 @@ func MustNotHaveKeyf(t Fatal, m, k any, format string, args ...any) {
 func Contains(t Error, iter, v any) bool {
 	if msg, ok := checkContains(iter, v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, iter, v)
+		msg = Report(t, "Contains", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -477,7 +578,9 @@ func Contains(t Error, iter, v any) bool {
 func Containsf(t Error, iter, v any, format string, args ...any) bool {
 	if msg, ok := checkContains(iter, v); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, iter, v)
+		msg = Report(t, "Containsf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -488,6 +591,8 @@ func Containsf(t Error, iter, v any, format string, args ...any) bool {
 func MustContain(t Fatal, iter, v any) {
 	if msg, ok := checkContains(iter, v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, iter, v)
+		msg = Report(t, "MustContain", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -496,7 +601,9 @@ func MustContain(t Fatal, iter, v any) {
 func MustContainf(t Fatal, iter, v any, format string, args ...any) {
 	if msg, ok := checkContains(iter, v); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, iter, v)
+		msg = Report(t, "MustContainf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -504,6 +611,8 @@ func MustContainf(t Fatal, iter, v any, format string, args ...any) {
 func NotContains(t Error, iter, v any) bool {
 	if msg, ok := checkNotContains(iter, v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, iter, v)
+		msg = Report(t, "NotContains", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -515,7 +624,9 @@ func NotContains(t Error, iter, v any) bool {
 func NotContainsf(t Error, iter, v any, format string, args ...any) bool {
 	if msg, ok := checkNotContains(iter, v); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, iter, v)
+		msg = Report(t, "NotContainsf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -526,6 +637,8 @@ func NotContainsf(t Error, iter, v any, format string, args ...any) bool {
 func MustNotContain(t Fatal, iter, v any) {
 	if msg, ok := checkNotContains(iter, v); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, iter, v)
+		msg = Report(t, "MustNotContain", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -534,7 +647,423 @@ func MustNotContain(t Fatal, iter, v any) {
 func MustNotContainf(t Fatal, iter, v any, format string, args ...any) {
 	if msg, ok := checkNotContains(iter, v); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, iter, v)
+		msg = Report(t, "MustNotContainf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that every element of g is in e; e is the expected value, g is what was got. Slices and arrays are compared as multisets, so duplicates must be matched by as many elements; maps must match by key and value.
+func Subset(t Error, g, e any) bool {
+	if msg, ok := checkSubset(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "Subset", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that every element of g is in e; e is the expected value, g is what was got. Slices and arrays are compared as multisets, so duplicates must be matched by as many elements; maps must match by key and value.
+func Subsetf(t Error, g, e any, format string, args ...any) bool {
+	if msg, ok := checkSubset(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "Subsetf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that every element of g is in e; e is the expected value, g is what was got. Slices and arrays are compared as multisets, so duplicates must be matched by as many elements; maps must match by key and value.
+func MustBeSubset(t Fatal, g, e any) {
+	if msg, ok := checkSubset(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "MustBeSubset", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that every element of g is in e; e is the expected value, g is what was got. Slices and arrays are compared as multisets, so duplicates must be matched by as many elements; maps must match by key and value.
+func MustBeSubsetf(t Fatal, g, e any, format string, args ...any) {
+	if msg, ok := checkSubset(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "MustBeSubsetf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that every element of e is in g; e is the expected value, g is what was got. Slices and arrays are compared as multisets, so duplicates must be matched by as many elements; maps must match by key and value.
+func Superset(t Error, g, e any) bool {
+	if msg, ok := checkSuperset(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "Superset", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that every element of e is in g; e is the expected value, g is what was got. Slices and arrays are compared as multisets, so duplicates must be matched by as many elements; maps must match by key and value.
+func Supersetf(t Error, g, e any, format string, args ...any) bool {
+	if msg, ok := checkSuperset(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "Supersetf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that every element of e is in g; e is the expected value, g is what was got. Slices and arrays are compared as multisets, so duplicates must be matched by as many elements; maps must match by key and value.
+func MustBeSuperset(t Fatal, g, e any) {
+	if msg, ok := checkSuperset(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "MustBeSuperset", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that every element of e is in g; e is the expected value, g is what was got. Slices and arrays are compared as multisets, so duplicates must be matched by as many elements; maps must match by key and value.
+func MustBeSupersetf(t Fatal, g, e any, format string, args ...any) {
+	if msg, ok := checkSuperset(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "MustBeSupersetf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that two pointers, chans, or maps are identical, ie. they point to the same thing; e is the expected value, g is what was got.
+func Same(t Error, g, e any) bool {
+	if msg, ok := checkSame(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "Same", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two pointers, chans, or maps are identical, ie. they point to the same thing; e is the expected value, g is what was got.
+func Samef(t Error, g, e any, format string, args ...any) bool {
+	if msg, ok := checkSame(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "Samef", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two pointers, chans, or maps are identical, ie. they point to the same thing; e is the expected value, g is what was got.
+func MustSame(t Fatal, g, e any) {
+	if msg, ok := checkSame(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "MustSame", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that two pointers, chans, or maps are identical, ie. they point to the same thing; e is the expected value, g is what was got.
+func MustSamef(t Fatal, g, e any, format string, args ...any) {
+	if msg, ok := checkSame(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "MustSamef", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that two pointers, chans, or maps are not identical, ie. they point to different things; e is the expected value, g is what was got.
+func NotSame(t Error, g, e any) bool {
+	if msg, ok := checkNotSame(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "NotSame", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two pointers, chans, or maps are not identical, ie. they point to different things; e is the expected value, g is what was got.
+func NotSamef(t Error, g, e any, format string, args ...any) bool {
+	if msg, ok := checkNotSame(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "NotSamef", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two pointers, chans, or maps are not identical, ie. they point to different things; e is the expected value, g is what was got.
+func MustNotSame(t Fatal, g, e any) {
+	if msg, ok := checkNotSame(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "MustNotSame", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that two pointers, chans, or maps are not identical, ie. they point to different things; e is the expected value, g is what was got.
+func MustNotSamef(t Fatal, g, e any, format string, args ...any) {
+	if msg, ok := checkNotSame(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "MustNotSamef", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that two texts are equal, diffing them line by line; e is the expected value, g is what was got. e may be written as an indented raw string literal: it's dedented and a leading newline is removed. Line endings and trailing whitespace are ignored.
+func Text(t Error, g, e string) bool {
+	if msg, ok := checkText(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, NoExpr, NoExpr)
+		msg = Report(t, "Text", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two texts are equal, diffing them line by line; e is the expected value, g is what was got. e may be written as an indented raw string literal: it's dedented and a leading newline is removed. Line endings and trailing whitespace are ignored.
+func Textf(t Error, g, e string, format string, args ...any) bool {
+	if msg, ok := checkText(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, NoExpr, NoExpr)
+		msg = Report(t, "Textf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two texts are equal, diffing them line by line; e is the expected value, g is what was got. e may be written as an indented raw string literal: it's dedented and a leading newline is removed. Line endings and trailing whitespace are ignored.
+func MustText(t Fatal, g, e string) {
+	if msg, ok := checkText(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, NoExpr, NoExpr)
+		msg = Report(t, "MustText", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that two texts are equal, diffing them line by line; e is the expected value, g is what was got. e may be written as an indented raw string literal: it's dedented and a leading newline is removed. Line endings and trailing whitespace are ignored.
+func MustTextf(t Fatal, g, e string, format string, args ...any) {
+	if msg, ok := checkText(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, NoExpr, NoExpr)
+		msg = Report(t, "MustTextf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that two texts are equal, like [Text], but dedent g too.
+func TextDedent(t Error, g, e string) bool {
+	if msg, ok := checkTextDedent(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, NoExpr, NoExpr)
+		msg = Report(t, "TextDedent", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two texts are equal, like [Text], but dedent g too.
+func TextDedentf(t Error, g, e string, format string, args ...any) bool {
+	if msg, ok := checkTextDedent(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, NoExpr, NoExpr)
+		msg = Report(t, "TextDedentf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two texts are equal, like [Text], but dedent g too.
+func MustTextDedent(t Fatal, g, e string) {
+	if msg, ok := checkTextDedent(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, NoExpr, NoExpr)
+		msg = Report(t, "MustTextDedent", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that two texts are equal, like [Text], but dedent g too.
+func MustTextDedentf(t Fatal, g, e string, format string, args ...any) {
+	if msg, ok := checkTextDedent(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, NoExpr, NoExpr)
+		msg = Report(t, "MustTextDedentf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that two times are the same instant, using [time.Time.Equal], so monotonic clock readings and locations don't matter; e is the expected value, g is what was got.
+func TimeEqual(t Error, g, e time.Time) bool {
+	if msg, ok := checkTimeEqual(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "TimeEqual", msg, g, e)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two times are the same instant, using [time.Time.Equal], so monotonic clock readings and locations don't matter; e is the expected value, g is what was got.
+func TimeEqualf(t Error, g, e time.Time, format string, args ...any) bool {
+	if msg, ok := checkTimeEqual(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "TimeEqualf", msg, g, e)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two times are the same instant, using [time.Time.Equal], so monotonic clock readings and locations don't matter; e is the expected value, g is what was got.
+func MustTimeEqual(t Fatal, g, e time.Time) {
+	if msg, ok := checkTimeEqual(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "MustTimeEqual", msg, g, e)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that two times are the same instant, using [time.Time.Equal], so monotonic clock readings and locations don't matter; e is the expected value, g is what was got.
+func MustTimeEqualf(t Fatal, g, e time.Time, format string, args ...any) {
+	if msg, ok := checkTimeEqual(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "MustTimeEqualf", msg, g, e)
+		t.Fatal(msg)
+	}
+}
+
+// Check that two times are at most d apart; e is the expected value, g is what was got.
+func WithinDuration(t Error, g, e time.Time, d time.Duration) bool {
+	if msg, ok := checkWithinDuration(g, e, d); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e, d)
+		msg = Report(t, "WithinDuration", msg, g, e)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two times are at most d apart; e is the expected value, g is what was got.
+func WithinDurationf(t Error, g, e time.Time, d time.Duration, format string, args ...any) bool {
+	if msg, ok := checkWithinDuration(g, e, d); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e, d)
+		msg = Report(t, "WithinDurationf", msg, g, e)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two times are at most d apart; e is the expected value, g is what was got.
+func MustWithinDuration(t Fatal, g, e time.Time, d time.Duration) {
+	if msg, ok := checkWithinDuration(g, e, d); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e, d)
+		msg = Report(t, "MustWithinDuration", msg, g, e)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that two times are at most d apart; e is the expected value, g is what was got.
+func MustWithinDurationf(t Fatal, g, e time.Time, d time.Duration, format string, args ...any) {
+	if msg, ok := checkWithinDuration(g, e, d); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e, d)
+		msg = Report(t, "MustWithinDurationf", msg, g, e)
+		t.Fatal(msg)
+	}
+}
+
+// Check that g is between start and end, inclusive.
+func TimeBetween(t Error, g, start, end time.Time) bool {
+	if msg, ok := checkTimeBetween(g, start, end); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, start, end)
+		msg = Report(t, "TimeBetween", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that g is between start and end, inclusive.
+func TimeBetweenf(t Error, g, start, end time.Time, format string, args ...any) bool {
+	if msg, ok := checkTimeBetween(g, start, end); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, start, end)
+		msg = Report(t, "TimeBetweenf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that g is between start and end, inclusive.
+func MustTimeBetween(t Fatal, g, start, end time.Time) {
+	if msg, ok := checkTimeBetween(g, start, end); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, start, end)
+		msg = Report(t, "MustTimeBetween", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that g is between start and end, inclusive.
+func MustTimeBetweenf(t Fatal, g, start, end time.Time, format string, args ...any) {
+	if msg, ok := checkTimeBetween(g, start, end); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, start, end)
+		msg = Report(t, "MustTimeBetweenf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -542,6 +1071,8 @@ func MustNotContainf(t Fatal, iter, v any, format string, args ...any) {
 func Panics(t Error, fn func()) bool {
 	if msg, ok := checkPanics(fn); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, fn)
+		msg = Report(t, "Panics", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -553,7 +1084,9 @@ func Panics(t Error, fn func()) bool {
 func Panicsf(t Error, fn func(), format string, args ...any) bool {
 	if msg, ok := checkPanics(fn); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, fn)
+		msg = Report(t, "Panicsf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -564,6 +1097,8 @@ func Panicsf(t Error, fn func(), format string, args ...any) bool {
 func MustPanic(t Fatal, fn func()) {
 	if msg, ok := checkPanics(fn); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, fn)
+		msg = Report(t, "MustPanic", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -572,7 +1107,9 @@ func MustPanic(t Fatal, fn func()) {
 func MustPanicf(t Fatal, fn func(), format string, args ...any) {
 	if msg, ok := checkPanics(fn); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, fn)
+		msg = Report(t, "MustPanicf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -580,6 +1117,8 @@ func MustPanicf(t Fatal, fn func(), format string, args ...any) {
 func NotPanics(t Error, fn func()) bool {
 	if msg, ok := checkNotPanics(fn); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, fn)
+		msg = Report(t, "NotPanics", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -591,7 +1130,9 @@ func NotPanics(t Error, fn func()) bool {
 func NotPanicsf(t Error, fn func(), format string, args ...any) bool {
 	if msg, ok := checkNotPanics(fn); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, fn)
+		msg = Report(t, "NotPanicsf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -602,6 +1143,8 @@ func NotPanicsf(t Error, fn func(), format string, args ...any) bool {
 func MustNotPanic(t Fatal, fn func()) {
 	if msg, ok := checkNotPanics(fn); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, fn)
+		msg = Report(t, "MustNotPanic", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -610,7 +1153,9 @@ func MustNotPanic(t Fatal, fn func()) {
 func MustNotPanicf(t Fatal, fn func(), format string, args ...any) {
 	if msg, ok := checkNotPanics(fn); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, fn)
+		msg = Report(t, "MustNotPanicf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -618,6 +1163,8 @@ func MustNotPanicf(t Fatal, fn func(), format string, args ...any) {
 func PanicsWith(t Error, recovers any, fn func()) bool {
 	if msg, ok := checkPanicsWith(recovers, fn); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, recovers, fn)
+		msg = Report(t, "PanicsWith", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -629,7 +1176,9 @@ func PanicsWith(t Error, recovers any, fn func()) bool {
 func PanicsWithf(t Error, recovers any, fn func(), format string, args ...any) bool {
 	if msg, ok := checkPanicsWith(recovers, fn); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, recovers, fn)
+		msg = Report(t, "PanicsWithf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -640,6 +1189,8 @@ func PanicsWithf(t Error, recovers any, fn func(), format string, args ...any) b
 func MustPanicWith(t Fatal, recovers any, fn func()) {
 	if msg, ok := checkPanicsWith(recovers, fn); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, recovers, fn)
+		msg = Report(t, "MustPanicWith", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -648,7 +1199,9 @@ func MustPanicWith(t Fatal, recovers any, fn func()) {
 func MustPanicWithf(t Fatal, recovers any, fn func(), format string, args ...any) {
 	if msg, ok := checkPanicsWith(recovers, fn); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, recovers, fn)
+		msg = Report(t, "MustPanicWithf", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -656,6 +1209,8 @@ func MustPanicWithf(t Fatal, recovers any, fn func(), format string, args ...any
 func EventuallyTrue(t Error, numTries int, fn func(i int) bool) bool {
 	if msg, ok := checkEventuallyTrue(numTries, fn); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, numTries, fn)
+		msg = Report(t, "EventuallyTrue", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -667,7 +1222,9 @@ func EventuallyTrue(t Error, numTries int, fn func(i int) bool) bool {
 func EventuallyTruef(t Error, numTries int, fn func(i int) bool, format string, args ...any) bool {
 	if msg, ok := checkEventuallyTrue(numTries, fn); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, numTries, fn)
+		msg = Report(t, "EventuallyTruef", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -678,6 +1235,8 @@ func EventuallyTruef(t Error, numTries int, fn func(i int) bool, format string,
 func MustEventuallyTrue(t Fatal, numTries int, fn func(i int) bool) {
 	if msg, ok := checkEventuallyTrue(numTries, fn); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, numTries, fn)
+		msg = Report(t, "MustEventuallyTrue", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -686,7 +1245,9 @@ func MustEventuallyTrue(t Fatal, numTries int, fn func(i int) bool) {
 func MustEventuallyTruef(t Fatal, numTries int, fn func(i int) bool, format string, args ...any) {
 	if msg, ok := checkEventuallyTrue(numTries, fn); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, numTries, fn)
+		msg = Report(t, "MustEventuallyTruef", msg)
+		t.Fatal(msg)
 	}
 }
 
@@ -694,6 +1255,8 @@ func MustEventuallyTruef(t Fatal, numTries int, fn func(i int) bool, format stri
 func EventuallyNil(t Error, numTries int, fn func(i int) error) bool {
 	if msg, ok := checkEventuallyNil(numTries, fn); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, numTries, fn)
+		msg = Report(t, "EventuallyNil", msg)
 		t.Error("\n" + msg)
 		return false
 	}
@@ -705,7 +1268,9 @@ func EventuallyNil(t Error, numTries int, fn func(i int) error) bool {
 func EventuallyNilf(t Error, numTries int, fn func(i int) error, format string, args ...any) bool {
 	if msg, ok := checkEventuallyNil(numTries, fn); !ok {
 		t.Helper()
-		t.Error(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, numTries, fn)
+		msg = Report(t, "EventuallyNilf", msg)
+		t.Error(msg)
 		return false
 	}
 
@@ -716,6 +1281,8 @@ func EventuallyNilf(t Error, numTries int, fn func(i int) error, format string,
 func MustEventuallyNil(t Fatal, numTries int, fn func(i int) error) {
 	if msg, ok := checkEventuallyNil(numTries, fn); !ok {
 		t.Helper()
+		msg = ExprMsg(msg, numTries, fn)
+		msg = Report(t, "MustEventuallyNil", msg)
 		t.Fatal("\n" + msg)
 	}
 }
@@ -724,6 +1291,284 @@ func MustEventuallyNil(t Fatal, numTries int, fn func(i int) error) {
 func MustEventuallyNilf(t Fatal, numTries int, fn func(i int) error, format string, args ...any) {
 	if msg, ok := checkEventuallyNil(numTries, fn); !ok {
 		t.Helper()
-		t.Fatal(fmt.Sprintf(format, args...) + "\n" + msg)
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, numTries, fn)
+		msg = Report(t, "MustEventuallyNilf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that fn makes at most n allocations per run, on average, as measured by [testing.AllocsPerRun].
+func AllocsAtMost(t Error, n float64, fn func()) bool {
+	if msg, ok := checkAllocsAtMost(n, fn); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, n, fn)
+		msg = Report(t, "AllocsAtMost", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that fn makes at most n allocations per run, on average, as measured by [testing.AllocsPerRun].
+func AllocsAtMostf(t Error, n float64, fn func(), format string, args ...any) bool {
+	if msg, ok := checkAllocsAtMost(n, fn); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, n, fn)
+		msg = Report(t, "AllocsAtMostf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that fn makes at most n allocations per run, on average, as measured by [testing.AllocsPerRun].
+func MustAllocsAtMost(t Fatal, n float64, fn func()) {
+	if msg, ok := checkAllocsAtMost(n, fn); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, n, fn)
+		msg = Report(t, "MustAllocsAtMost", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that fn makes at most n allocations per run, on average, as measured by [testing.AllocsPerRun].
+func MustAllocsAtMostf(t Fatal, n float64, fn func(), format string, args ...any) {
+	if msg, ok := checkAllocsAtMost(n, fn); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, n, fn)
+		msg = Report(t, "MustAllocsAtMostf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that fn runs within budget. fn is run a few times to warm up, then the median of several timed runs is compared to budget.
+func FasterThan(t Error, budget time.Duration, fn func()) bool {
+	if msg, ok := checkFasterThan(budget, fn); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, budget, fn)
+		msg = Report(t, "FasterThan", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that fn runs within budget. fn is run a few times to warm up, then the median of several timed runs is compared to budget.
+func FasterThanf(t Error, budget time.Duration, fn func(), format string, args ...any) bool {
+	if msg, ok := checkFasterThan(budget, fn); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, budget, fn)
+		msg = Report(t, "FasterThanf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that fn runs within budget. fn is run a few times to warm up, then the median of several timed runs is compared to budget.
+func MustFasterThan(t Fatal, budget time.Duration, fn func()) {
+	if msg, ok := checkFasterThan(budget, fn); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, budget, fn)
+		msg = Report(t, "MustFasterThan", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that fn runs within budget. fn is run a few times to warm up, then the median of several timed runs is compared to budget.
+func MustFasterThanf(t Fatal, budget time.Duration, fn func(), format string, args ...any) {
+	if msg, ok := checkFasterThan(budget, fn); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, budget, fn)
+		msg = Report(t, "MustFasterThanf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that path exists and is a file.
+func FileExists(t Error, path string) bool {
+	if msg, ok := checkFileExists(path); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, path)
+		msg = Report(t, "FileExists", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that path exists and is a file.
+func FileExistsf(t Error, path string, format string, args ...any) bool {
+	if msg, ok := checkFileExists(path); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, path)
+		msg = Report(t, "FileExistsf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that path exists and is a file.
+func MustFileExists(t Fatal, path string) {
+	if msg, ok := checkFileExists(path); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, path)
+		msg = Report(t, "MustFileExists", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that path exists and is a file.
+func MustFileExistsf(t Fatal, path string, format string, args ...any) {
+	if msg, ok := checkFileExists(path); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, path)
+		msg = Report(t, "MustFileExistsf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that path exists and is a directory.
+func DirExists(t Error, path string) bool {
+	if msg, ok := checkDirExists(path); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, path)
+		msg = Report(t, "DirExists", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that path exists and is a directory.
+func DirExistsf(t Error, path string, format string, args ...any) bool {
+	if msg, ok := checkDirExists(path); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, path)
+		msg = Report(t, "DirExistsf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that path exists and is a directory.
+func MustDirExists(t Fatal, path string) {
+	if msg, ok := checkDirExists(path); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, path)
+		msg = Report(t, "MustDirExists", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that path exists and is a directory.
+func MustDirExistsf(t Fatal, path string, format string, args ...any) {
+	if msg, ok := checkDirExists(path); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, path)
+		msg = Report(t, "MustDirExistsf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that the file at path contains exactly want.
+func FileContent(t Error, path, want string) bool {
+	if msg, ok := checkFileContent(path, want); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, path, want)
+		msg = Report(t, "FileContent", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the file at path contains exactly want.
+func FileContentf(t Error, path, want string, format string, args ...any) bool {
+	if msg, ok := checkFileContent(path, want); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, path, want)
+		msg = Report(t, "FileContentf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the file at path contains exactly want.
+func MustHaveFileContent(t Fatal, path, want string) {
+	if msg, ok := checkFileContent(path, want); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, path, want)
+		msg = Report(t, "MustHaveFileContent", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that the file at path contains exactly want.
+func MustHaveFileContentf(t Fatal, path, want string, format string, args ...any) {
+	if msg, ok := checkFileContent(path, want); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, path, want)
+		msg = Report(t, "MustHaveFileContentf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that two trees of files are equal. Each tree is either a directory path or an [fs.FS], eg. from [Txtar]; only regular files are compared.
+func TreeEqual(t Error, g, e any) bool {
+	if msg, ok := checkTreeEqual(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "TreeEqual", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two trees of files are equal. Each tree is either a directory path or an [fs.FS], eg. from [Txtar]; only regular files are compared.
+func TreeEqualf(t Error, g, e any, format string, args ...any) bool {
+	if msg, ok := checkTreeEqual(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "TreeEqualf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that two trees of files are equal. Each tree is either a directory path or an [fs.FS], eg. from [Txtar]; only regular files are compared.
+func MustTreeEqual(t Fatal, g, e any) {
+	if msg, ok := checkTreeEqual(g, e); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, g, e)
+		msg = Report(t, "MustTreeEqual", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that two trees of files are equal. Each tree is either a directory path or an [fs.FS], eg. from [Txtar]; only regular files are compared.
+func MustTreeEqualf(t Fatal, g, e any, format string, args ...any) {
+	if msg, ok := checkTreeEqual(g, e); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + ExprMsg(msg, g, e)
+		msg = Report(t, "MustTreeEqualf", msg)
+		t.Fatal(msg)
 	}
 }
@@ -225,3 +225,7 @@ func TestCheckEventuallyNil(t *testing.T) {
 	testCheck(checkEventuallyNil(100, func(i int) error { return nil }))(t, true)
 	testCheck(checkEventuallyNil(100, func(i int) error { return os.ErrClosed }))(t, false)
 }
+
+func TestDiff(t *testing.T) {
+	Equal(t, Diff(1, 2), equalMsg(1, 2))
+}
@@ -0,0 +1,64 @@
+//go:build generate
+
+//go:generate go run $GOFILE
+
+package main
+
+import (
+	"github.com/thatguystone/cog/generate"
+	"github.com/thatguystone/cog/generate/checkgen"
+)
+
+func main() {
+	b := generate.New()
+	checkgen.Generate(b, funcs...)
+	b.WriteFile()
+}
+
+var funcs = []checkgen.Func{
+	{
+		Name:    "Status",
+		Must:    "HaveStatus",
+		Args:    "resp any, code int",
+		NoExprs: "resp",
+		Check:   "checkStatus(resp, code)",
+		Doc:     "Check that the response has the given status code.",
+	},
+	{
+		Name:    "HasHeader",
+		Must:    "HaveHeader",
+		Args:    "resp any, key string",
+		NoExprs: "resp",
+		Check:   "checkHasHeader(resp, key)",
+		Doc:     "Check that the response has the given header.",
+	},
+	{
+		Name:    "HeaderEqual",
+		Args:    "resp any, key, want string",
+		NoExprs: "resp",
+		Check:   "checkHeaderEqual(resp, key, want)",
+		Doc:     "Check that the response has exactly one value for the given header, and that it's equal to want.",
+	},
+	{
+		Name:    "BodyEqual",
+		Args:    "resp any, want string",
+		NoExprs: "resp",
+		Check:   "checkBodyEqual(resp, want)",
+		Doc:     "Check that the response body is equal to want.",
+	},
+	{
+		Name:    "JSONBodyEqual",
+		Args:    "resp any, want string",
+		NoExprs: "resp",
+		Check:   "checkJSONBodyEqual(resp, want)",
+		Doc:     "Check that the response body and want hold equal JSON values, ignoring formatting and object key order.",
+	},
+	{
+		Name:    "BodyContains",
+		Must:    "BodyContain",
+		Args:    "resp any, substr string",
+		NoExprs: "resp",
+		Check:   "checkBodyContains(resp, substr)",
+		Doc:     "Check that the response body contains substr.",
+	},
+}
@@ -0,0 +1,210 @@
+// Package checkhttp implements checks for HTTP responses, from either an
+// [httptest.ResponseRecorder] or an [http.Response]
+package checkhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+
+	"github.com/thatguystone/cog/check"
+	"github.com/thatguystone/cog/textwrap"
+)
+
+// response is a snapshot of a response, as shown in failure messages
+type response struct {
+	Status string
+	Header http.Header
+	Body   string
+}
+
+// request is the part of a request shown in failure messages; by the time a
+// response is checked, the request body has usually been consumed.
+type request struct {
+	Method string
+	URL    string
+	Header http.Header
+}
+
+// getResponse snapshots resp, which must be a *httptest.ResponseRecorder or
+// *http.Response. The body of an *http.Response is replaced so that it can
+// still be read afterwards.
+func getResponse(resp any) (*http.Response, *response, error) {
+	var res *http.Response
+	switch resp := resp.(type) {
+	case *httptest.ResponseRecorder:
+		res = resp.Result()
+	case *http.Response:
+		res = resp
+	default:
+		return nil, nil, fmt.Errorf(
+			"unsupported response type %T; must be *httptest.ResponseRecorder or *http.Response",
+			resp,
+		)
+	}
+
+	var body []byte
+	if res.Body != nil {
+		var err error
+		body, err = io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read body: %w", err)
+		}
+	}
+
+	return res, &response{
+		Status: res.Status,
+		Header: res.Header,
+		Body:   string(body),
+	}, nil
+}
+
+// failMsg builds a failure message that shows the request, if known, and the
+// response
+func failMsg(res *http.Response, snap *response, format string, args ...any) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, format, args...)
+
+	if req := res.Request; req != nil {
+		b.WriteString("\nRequest:\n")
+		b.WriteString(indent(check.Dump(request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header,
+		})))
+	}
+
+	b.WriteString("\nResponse:\n")
+	b.WriteString(indent(check.Dump(*snap)))
+
+	return b.String()
+}
+
+func indent(s string) string {
+	return textwrap.Indent(s, "    ")
+}
+
+func checkStatus(resp any, code int) (string, bool) {
+	res, snap, err := getResponse(resp)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	if res.StatusCode == code {
+		return "", true
+	}
+
+	return failMsg(
+		res,
+		snap,
+		"Expected status %d %s, got %s",
+		code,
+		http.StatusText(code),
+		res.Status,
+	), false
+}
+
+func checkHasHeader(resp any, key string) (string, bool) {
+	res, snap, err := getResponse(resp)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	if _, ok := res.Header[http.CanonicalHeaderKey(key)]; ok {
+		return "", true
+	}
+
+	return failMsg(res, snap, "Expected header %q to be present", key), false
+}
+
+func checkHeaderEqual(resp any, key, want string) (string, bool) {
+	res, snap, err := getResponse(resp)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	vals, ok := res.Header[http.CanonicalHeaderKey(key)]
+	switch {
+	case !ok:
+		return failMsg(res, snap, "Expected header %q to be present", key), false
+	case len(vals) == 1 && vals[0] == want:
+		return "", true
+	}
+
+	return failMsg(
+		res,
+		snap,
+		"Header %q:\n%s",
+		key,
+		indent(check.Diff(vals, []string{want})),
+	), false
+}
+
+func checkBodyEqual(resp any, want string) (string, bool) {
+	res, snap, err := getResponse(resp)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	if snap.Body == want {
+		return "", true
+	}
+
+	return failMsg(
+		res,
+		snap,
+		"Body:\n%s",
+		indent(check.Diff(snap.Body, want)),
+	), false
+}
+
+func checkJSONBodyEqual(resp any, want string) (string, bool) {
+	res, snap, err := getResponse(resp)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	var e any
+	err = json.Unmarshal([]byte(want), &e)
+	if err != nil {
+		return "Invalid JSON in want:\n" + indent(check.Dump(err)), false
+	}
+
+	var g any
+	err = json.Unmarshal([]byte(snap.Body), &g)
+	if err != nil {
+		return failMsg(res, snap, "Invalid JSON in body: %v", err), false
+	}
+
+	if reflect.DeepEqual(g, e) {
+		return "", true
+	}
+
+	return failMsg(
+		res,
+		snap,
+		"JSON body:\n%s",
+		indent(check.Diff(g, e)),
+	), false
+}
+
+func checkBodyContains(resp any, substr string) (string, bool) {
+	res, snap, err := getResponse(resp)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	if strings.Contains(snap.Body, substr) {
+		return "", true
+	}
+
+	return failMsg(res, snap, "Expected body to contain %q", substr), false
+}
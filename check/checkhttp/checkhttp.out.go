@@ -0,0 +1,287 @@
+// Code generated by `go generate checkhttp.cmd.go`. DO NOT EDIT.
+
+package checkhttp
+
+//gocovr:skip-file
+
+import (
+	"fmt"
+
+	"github.com/thatguystone/cog/check"
+)
+
+// Check that the response has the given status code.
+func Status(t check.Error, resp any, code int) bool {
+	if msg, ok := checkStatus(resp, code); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, code)
+		msg = check.Report(t, "Status", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response has the given status code.
+func Statusf(t check.Error, resp any, code int, format string, args ...any) bool {
+	if msg, ok := checkStatus(resp, code); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, code)
+		msg = check.Report(t, "Statusf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response has the given status code.
+func MustHaveStatus(t check.Fatal, resp any, code int) {
+	if msg, ok := checkStatus(resp, code); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, code)
+		msg = check.Report(t, "MustHaveStatus", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that the response has the given status code.
+func MustHaveStatusf(t check.Fatal, resp any, code int, format string, args ...any) {
+	if msg, ok := checkStatus(resp, code); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, code)
+		msg = check.Report(t, "MustHaveStatusf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that the response has the given header.
+func HasHeader(t check.Error, resp any, key string) bool {
+	if msg, ok := checkHasHeader(resp, key); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, key)
+		msg = check.Report(t, "HasHeader", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response has the given header.
+func HasHeaderf(t check.Error, resp any, key string, format string, args ...any) bool {
+	if msg, ok := checkHasHeader(resp, key); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, key)
+		msg = check.Report(t, "HasHeaderf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response has the given header.
+func MustHaveHeader(t check.Fatal, resp any, key string) {
+	if msg, ok := checkHasHeader(resp, key); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, key)
+		msg = check.Report(t, "MustHaveHeader", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that the response has the given header.
+func MustHaveHeaderf(t check.Fatal, resp any, key string, format string, args ...any) {
+	if msg, ok := checkHasHeader(resp, key); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, key)
+		msg = check.Report(t, "MustHaveHeaderf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that the response has exactly one value for the given header, and that it's equal to want.
+func HeaderEqual(t check.Error, resp any, key, want string) bool {
+	if msg, ok := checkHeaderEqual(resp, key, want); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, key, want)
+		msg = check.Report(t, "HeaderEqual", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response has exactly one value for the given header, and that it's equal to want.
+func HeaderEqualf(t check.Error, resp any, key, want string, format string, args ...any) bool {
+	if msg, ok := checkHeaderEqual(resp, key, want); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, key, want)
+		msg = check.Report(t, "HeaderEqualf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response has exactly one value for the given header, and that it's equal to want.
+func MustHeaderEqual(t check.Fatal, resp any, key, want string) {
+	if msg, ok := checkHeaderEqual(resp, key, want); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, key, want)
+		msg = check.Report(t, "MustHeaderEqual", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that the response has exactly one value for the given header, and that it's equal to want.
+func MustHeaderEqualf(t check.Fatal, resp any, key, want string, format string, args ...any) {
+	if msg, ok := checkHeaderEqual(resp, key, want); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, key, want)
+		msg = check.Report(t, "MustHeaderEqualf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that the response body is equal to want.
+func BodyEqual(t check.Error, resp any, want string) bool {
+	if msg, ok := checkBodyEqual(resp, want); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, want)
+		msg = check.Report(t, "BodyEqual", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response body is equal to want.
+func BodyEqualf(t check.Error, resp any, want string, format string, args ...any) bool {
+	if msg, ok := checkBodyEqual(resp, want); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, want)
+		msg = check.Report(t, "BodyEqualf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response body is equal to want.
+func MustBodyEqual(t check.Fatal, resp any, want string) {
+	if msg, ok := checkBodyEqual(resp, want); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, want)
+		msg = check.Report(t, "MustBodyEqual", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that the response body is equal to want.
+func MustBodyEqualf(t check.Fatal, resp any, want string, format string, args ...any) {
+	if msg, ok := checkBodyEqual(resp, want); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, want)
+		msg = check.Report(t, "MustBodyEqualf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that the response body and want hold equal JSON values, ignoring formatting and object key order.
+func JSONBodyEqual(t check.Error, resp any, want string) bool {
+	if msg, ok := checkJSONBodyEqual(resp, want); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, want)
+		msg = check.Report(t, "JSONBodyEqual", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response body and want hold equal JSON values, ignoring formatting and object key order.
+func JSONBodyEqualf(t check.Error, resp any, want string, format string, args ...any) bool {
+	if msg, ok := checkJSONBodyEqual(resp, want); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, want)
+		msg = check.Report(t, "JSONBodyEqualf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response body and want hold equal JSON values, ignoring formatting and object key order.
+func MustJSONBodyEqual(t check.Fatal, resp any, want string) {
+	if msg, ok := checkJSONBodyEqual(resp, want); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, want)
+		msg = check.Report(t, "MustJSONBodyEqual", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that the response body and want hold equal JSON values, ignoring formatting and object key order.
+func MustJSONBodyEqualf(t check.Fatal, resp any, want string, format string, args ...any) {
+	if msg, ok := checkJSONBodyEqual(resp, want); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, want)
+		msg = check.Report(t, "MustJSONBodyEqualf", msg)
+		t.Fatal(msg)
+	}
+}
+
+// Check that the response body contains substr.
+func BodyContains(t check.Error, resp any, substr string) bool {
+	if msg, ok := checkBodyContains(resp, substr); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, substr)
+		msg = check.Report(t, "BodyContains", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response body contains substr.
+func BodyContainsf(t check.Error, resp any, substr string, format string, args ...any) bool {
+	if msg, ok := checkBodyContains(resp, substr); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, substr)
+		msg = check.Report(t, "BodyContainsf", msg)
+		t.Error(msg)
+		return false
+	}
+
+	return true
+}
+
+// Check that the response body contains substr.
+func MustBodyContain(t check.Fatal, resp any, substr string) {
+	if msg, ok := checkBodyContains(resp, substr); !ok {
+		t.Helper()
+		msg = check.ExprMsg(msg, check.NoExpr, substr)
+		msg = check.Report(t, "MustBodyContain", msg)
+		t.Fatal("\n" + msg)
+	}
+}
+
+// Check that the response body contains substr.
+func MustBodyContainf(t check.Fatal, resp any, substr string, format string, args ...any) {
+	if msg, ok := checkBodyContains(resp, substr); !ok {
+		t.Helper()
+		msg = fmt.Sprintf(format, args...) + "\n" + check.ExprMsg(msg, check.NoExpr, substr)
+		msg = check.Report(t, "MustBodyContainf", msg)
+		t.Fatal(msg)
+	}
+}
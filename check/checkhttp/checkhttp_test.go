@@ -0,0 +1,157 @@
+package checkhttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/thatguystone/cog/check"
+	"github.com/thatguystone/cog/check/checktest"
+)
+
+func newRecorder() *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	rec.Header().Add("Vary", "a")
+	rec.Header().Add("Vary", "b")
+	rec.WriteHeader(http.StatusNotFound)
+	rec.WriteString(`{"a": 1, "b": [true]}`)
+	return rec
+}
+
+func TestGetResponse(t *testing.T) {
+	res, snap, err := getResponse(newRecorder())
+	check.MustNil(t, err)
+	check.Equal(t, res.StatusCode, http.StatusNotFound)
+	check.Equal(t, snap.Body, `{"a": 1, "b": [true]}`)
+
+	// The body can still be read
+	body, err := io.ReadAll(res.Body)
+	check.MustNil(t, err)
+	check.Equal(t, string(body), snap.Body)
+
+	res = &http.Response{
+		Status: "200 OK",
+		Body:   io.NopCloser(strings.NewReader("body")),
+	}
+
+	_, snap, err = getResponse(res)
+	check.MustNil(t, err)
+	check.Equal(t, snap.Body, "body")
+
+	body, err = io.ReadAll(res.Body)
+	check.MustNil(t, err)
+	check.Equal(t, string(body), "body")
+
+	_, _, err = getResponse(&http.Response{})
+	check.Nil(t, err)
+
+	_, _, err = getResponse(1)
+	check.NotNil(t, err)
+}
+
+func TestFailMsg(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/path")
+	check.MustNil(t, err)
+	defer res.Body.Close()
+
+	msg, ok := checkStatus(res, http.StatusOK)
+	check.False(t, ok)
+	check.Contains(t, msg, "Expected status 200 OK, got 418 I'm a teapot\n")
+	check.Contains(t, msg, "Request:\n    checkhttp.request{\n        Method: \"GET\",\n")
+	check.Contains(t, msg, "Response:\n    checkhttp.response{\n")
+	check.Contains(t, msg, `Body: "nope\n",`)
+
+	msg, _ = checkStatus(newRecorder(), http.StatusOK)
+	check.NotContains(t, msg, "Request:")
+}
+
+func TestStatus(t *testing.T) {
+	r := checktest.Run(func(r *checktest.Recorder) {
+		check.True(t, Status(r, newRecorder(), http.StatusNotFound))
+		MustHaveStatus(r, newRecorder(), http.StatusNotFound)
+	})
+	check.False(t, r.Failed())
+
+	r = checktest.Run(func(r *checktest.Recorder) {
+		code := http.StatusOK
+		check.False(t, Status(r, newRecorder(), code))
+		check.False(t, Statusf(r, nil, code, "request %d", 1))
+		MustHaveStatus(r, newRecorder(), http.StatusOK)
+	})
+	r.Golden(t, "testdata/status.golden")
+}
+
+func TestHasHeader(t *testing.T) {
+	r := checktest.Run(func(r *checktest.Recorder) {
+		check.True(t, HasHeader(r, newRecorder(), "content-type"))
+	})
+	check.False(t, r.Failed())
+
+	r = checktest.Run(func(r *checktest.Recorder) {
+		check.False(t, HasHeader(r, newRecorder(), "X-Nope"))
+		check.False(t, HasHeader(r, nil, "X-Nope"))
+	})
+	r.Golden(t, "testdata/has_header.golden")
+}
+
+func TestHeaderEqual(t *testing.T) {
+	r := checktest.Run(func(r *checktest.Recorder) {
+		check.True(t, HeaderEqual(r, newRecorder(), "Content-Type", "application/json"))
+	})
+	check.False(t, r.Failed())
+
+	r = checktest.Run(func(r *checktest.Recorder) {
+		check.False(t, HeaderEqual(r, newRecorder(), "Content-Type", "text/plain"))
+		check.False(t, HeaderEqual(r, newRecorder(), "Vary", "a"))
+		check.False(t, HeaderEqual(r, newRecorder(), "X-Nope", ""))
+	})
+	r.Golden(t, "testdata/header_equal.golden")
+}
+
+func TestBodyEqual(t *testing.T) {
+	r := checktest.Run(func(r *checktest.Recorder) {
+		check.True(t, BodyEqual(r, newRecorder(), `{"a": 1, "b": [true]}`))
+	})
+	check.False(t, r.Failed())
+
+	r = checktest.Run(func(r *checktest.Recorder) {
+		check.False(t, BodyEqual(r, newRecorder(), `{"a":1,"b":[true]}`))
+	})
+	r.Golden(t, "testdata/body_equal.golden")
+}
+
+func TestJSONBodyEqual(t *testing.T) {
+	r := checktest.Run(func(r *checktest.Recorder) {
+		check.True(t, JSONBodyEqual(r, newRecorder(), `{"b":[true],"a":1}`))
+	})
+	check.False(t, r.Failed())
+
+	r = checktest.Run(func(r *checktest.Recorder) {
+		check.False(t, JSONBodyEqual(r, newRecorder(), `{"b":[false],"a":1}`))
+		check.False(t, JSONBodyEqual(r, newRecorder(), `{`))
+		check.False(t, JSONBodyEqual(r, httptest.NewRecorder(), `{}`))
+	})
+	r.Golden(t, "testdata/json_body_equal.golden")
+}
+
+func TestBodyContains(t *testing.T) {
+	r := checktest.Run(func(r *checktest.Recorder) {
+		check.True(t, BodyContains(r, newRecorder(), `"a": 1`))
+	})
+	check.False(t, r.Failed())
+
+	r = checktest.Run(func(r *checktest.Recorder) {
+		want := `"a": 2`
+		check.False(t, BodyContains(r, newRecorder(), want))
+		MustBodyContain(r, nil, want)
+	})
+	r.Golden(t, "testdata/body_contains.golden")
+}
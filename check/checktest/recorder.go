@@ -0,0 +1,113 @@
+// Package checktest helps test custom checks built on top of package check
+package checktest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thatguystone/cog/check"
+)
+
+// Recorder is a fake [check.Error] and [check.Fatal] that records failure
+// messages instead of failing a test. Like [testing.T], Fatal stops the
+// calling goroutine with [runtime.Goexit], so anything that might call Fatal
+// should be run with [Run].
+type Recorder struct {
+	mtx    sync.Mutex
+	failed bool
+	msgs   []string
+}
+
+var (
+	_ check.Error = (*Recorder)(nil)
+	_ check.Fatal = (*Recorder)(nil)
+)
+
+// Run calls fn with a new Recorder on its own goroutine, returning once fn
+// returns or calls Fatal.
+func Run(fn func(r *Recorder)) *Recorder {
+	r := new(Recorder)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+
+	<-done
+	return r
+}
+
+// Helper implements [check.Error] and [check.Fatal]
+func (r *Recorder) Helper() {}
+
+// Error records a failure message, formatted like [testing.T.Error]
+func (r *Recorder) Error(args ...any) {
+	r.record(args)
+}
+
+// Fatal records a failure message, formatted like [testing.T.Fatal], and stops
+// the calling goroutine.
+func (r *Recorder) Fatal(args ...any) {
+	r.record(args)
+	runtime.Goexit()
+}
+
+func (r *Recorder) record(args []any) {
+	msg := fmt.Sprintln(args...)
+	msg = strings.TrimSuffix(msg, "\n")
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.failed = true
+	r.msgs = append(r.msgs, msg)
+}
+
+// Failed reports whether Error or Fatal was called
+func (r *Recorder) Failed() bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return r.failed
+}
+
+// Messages gets every message recorded so far
+func (r *Recorder) Messages() []string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return append([]string(nil), r.msgs...)
+}
+
+// Golden checks that the recorded messages match the contents of the file at
+// path. When the environment variable CHECK_UPDATE_GOLDEN=1 is set, the file
+// is written instead. Use [check.Config.Deterministic] to keep addresses out
+// of messages.
+func (r *Recorder) Golden(t testing.TB, path string) {
+	t.Helper()
+
+	got := strings.Join(r.Messages(), "\n---\n") + "\n"
+
+	if updateGolden {
+		err := os.MkdirAll(filepath.Dir(path), 0750)
+		check.MustNil(t, err)
+
+		err = os.WriteFile(path, []byte(got), 0640)
+		check.MustNil(t, err)
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	check.MustNilf(t, err, "set CHECK_UPDATE_GOLDEN=1 to create %s", path)
+
+	check.Equalf(t, got, string(want), "golden file %s is out of date", path)
+}
+
+var updateGolden, _ = strconv.ParseBool(os.Getenv("CHECK_UPDATE_GOLDEN"))
@@ -0,0 +1,49 @@
+package checktest
+
+import (
+	"testing"
+
+	"github.com/thatguystone/cog/check"
+)
+
+func TestRecorderError(t *testing.T) {
+	r := Run(func(r *Recorder) {
+		check.True(r, true)
+		check.Equal(r, 1, 2)
+		check.Equal(r, "a", "b")
+	})
+
+	check.True(t, r.Failed())
+	check.Equal(t, len(r.Messages()), 2)
+	r.Golden(t, "testdata/error.golden")
+}
+
+func TestRecorderFatal(t *testing.T) {
+	reached := false
+
+	r := Run(func(r *Recorder) {
+		check.MustEqual(r, 1, 2)
+		reached = true
+	})
+
+	check.True(t, r.Failed())
+	check.False(t, reached)
+	check.Equal(t, len(r.Messages()), 1)
+}
+
+func TestRecorderPasses(t *testing.T) {
+	r := Run(func(r *Recorder) {
+		check.MustTrue(r, true)
+	})
+
+	check.False(t, r.Failed())
+	check.Equal(t, r.Messages(), []string(nil))
+}
+
+func TestRecorderArgs(t *testing.T) {
+	r := Run(func(r *Recorder) {
+		r.Error("a", 1, "b")
+	})
+
+	check.Equal(t, r.Messages(), []string{"a 1 b"})
+}
@@ -0,0 +1,664 @@
+package check
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/peter-evans/patience"
+	"github.com/thatguystone/cog/textwrap"
+)
+
+type diffLine struct {
+	typ  patience.DiffType
+	text string
+	path []string
+}
+
+// String formats the line with its diff marker
+func (diff diffLine) String() string {
+	switch diff.typ {
+	case patience.Delete:
+		return "- " + diff.text
+	case patience.Insert:
+		return "+ " + diff.text
+	default:
+		return "  " + diff.text
+	}
+}
+
+// diffDumps line-diffs the output of two calls to dumpLines
+func diffDumps(g, e dumpedLines) []diffLine {
+	var (
+		diffs  = patience.Diff(g.lines, e.lines)
+		ret    = make([]diffLine, len(diffs))
+		gi, ei int
+	)
+
+	for i, diff := range diffs {
+		ret[i] = diffLine{
+			typ:  diff.Type,
+			text: diff.Text,
+		}
+
+		switch diff.Type {
+		case patience.Delete:
+			ret[i].path = g.paths[gi]
+			gi++
+		case patience.Insert:
+			ret[i].path = e.paths[ei]
+			ei++
+		default:
+			ret[i].path = g.paths[gi]
+			gi++
+			ei++
+		}
+	}
+
+	return ret
+}
+
+// diffState is shared by all the dumps and nested diffs that make up one
+// diff, so that circular values aren't followed forever, and so that addresses
+// get the same ids in got and want.
+type diffState struct {
+	visits  map[diffVisit]bool
+	addrIDs map[uint64]int
+}
+
+type diffVisit struct {
+	g, e uintptr
+	len  int
+	typ  reflect.Type
+}
+
+func newDiffState() *diffState {
+	return &diffState{
+		visits:  make(map[diffVisit]bool),
+		addrIDs: make(map[uint64]int),
+	}
+}
+
+// enter marks gv and ev as being diffed, from the outermost value inwards,
+// returning a func to unmark them, or false if they're already being diffed
+// further out
+func (st *diffState) enter(gv, ev reflect.Value) (func(), bool) {
+	visit := diffVisit{gv.Pointer(), ev.Pointer(), 0, gv.Type()}
+	if gv.Kind() == reflect.Slice {
+		visit.len = gv.Len()
+	}
+
+	if st.visits[visit] {
+		return nil, false
+	}
+
+	st.visits[visit] = true
+	return func() { delete(st.visits, visit) }, true
+}
+
+func (st *diffState) newDumper(initialIndent int) *dumper {
+	d := newDumper(initialIndent)
+	d.addrIDs = st.addrIDs
+	return d
+}
+
+func (st *diffState) dump(v any, initialIndent int) string {
+	return st.newDumper(initialIndent).dumpString(v)
+}
+
+func (st *diffState) dumpLines(v any) dumpedLines {
+	return st.newDumper(0).dumpLines(v)
+}
+
+// dumpPair dumps g and e to be diffed. If the limits in [Settings] hide
+// everything that differs, they're dumped again without limits, leaving the
+// diff to fold away everything that's the same.
+func (st *diffState) dumpPair(g, e any) (gd, ed dumpedLines) {
+	gd = st.dumpLines(g)
+	ed = st.dumpLines(e)
+
+	if (gd.truncated || ed.truncated) && slices.Equal(gd.lines, ed.lines) {
+		gd = st.fullDumpLines(g)
+		ed = st.fullDumpLines(e)
+	}
+
+	return
+}
+
+func (st *diffState) fullDumpLines(v any) dumpedLines {
+	d := st.newDumper(0)
+	d.maxDepth = 0
+	d.maxElems = 0
+	d.maxLen = 0
+	return d.dumpLines(v)
+}
+
+// writeValueDiff writes a description of how g and e differ, with each line
+// prefixed by prefix and terminated by a newline.
+func writeValueDiff(b *strings.Builder, g, e any, prefix string, st *diffState) {
+	var (
+		gv = reflect.ValueOf(g)
+		ev = reflect.ValueOf(e)
+	)
+
+	if gv.IsValid() && ev.IsValid() && gv.Type() == ev.Type() {
+		switch gv.Kind() {
+		case reflect.Map:
+			if gv.IsNil() || ev.IsNil() {
+				break
+			}
+
+			// Circular values are left to the dumper, which marks them
+			leave, ok := st.enter(gv, ev)
+			if !ok {
+				break
+			}
+
+			defer leave()
+			writeMapDiff(b, gv, ev, prefix, st)
+			return
+
+		case reflect.Slice, reflect.Array:
+			if gv.Kind() == reflect.Slice && (gv.IsNil() || ev.IsNil()) {
+				break
+			}
+
+			switch gv.Type().Elem().Kind() {
+			case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array,
+				reflect.Pointer, reflect.Interface:
+
+				if gv.Kind() == reflect.Slice {
+					leave, ok := st.enter(gv, ev)
+					if !ok {
+						break
+					}
+
+					defer leave()
+				}
+
+				if writeSliceDiff(b, gv, ev, prefix, st) {
+					return
+				}
+			}
+		}
+	}
+
+	gd, ed := st.dumpPair(g, e)
+	writeLineDiff(b, gd, ed, prefix)
+}
+
+// writeLineDiff line-diffs two dumps
+func writeLineDiff(b *strings.Builder, g, e dumpedLines, prefix string) {
+	writeDiff(b, diffDumps(g, e), Settings.DiffContext, prefix)
+
+	if slices.Equal(g.lines, e.lines) {
+		b.WriteString(prefix)
+		writeIdenticalNote(b)
+		b.WriteByte('\n')
+	}
+}
+
+// writeIdenticalNote explains that two values differ even though their dumps
+// are identical. Since dumps are redone without limits when the limits hide
+// the differences, this only happens when the differences can't be dumped,
+// eg. NaNs or funcs.
+func writeIdenticalNote(b *strings.Builder) {
+	b.WriteString("Values differ, but their dumps are identical")
+}
+
+// writeMapDiff diffs two maps of the same type key-by-key, so that entries
+// can't be misaligned.
+func writeMapDiff(b *strings.Builder, gv, ev reflect.Value, prefix string, st *diffState) {
+	var (
+		onlyG, onlyE, changed []kv
+		numSame               int
+	)
+
+	for _, gkv := range sortMap(gv) {
+		evv := ev.MapIndex(gkv.k)
+		switch {
+		case !evv.IsValid():
+			onlyG = append(onlyG, gkv)
+		case !deepEqual(gkv.v.Interface(), evv.Interface()):
+			changed = append(changed, gkv)
+		default:
+			numSame++
+		}
+	}
+
+	for _, ekv := range sortMap(ev) {
+		if !gv.MapIndex(ekv.k).IsValid() {
+			onlyE = append(onlyE, ekv)
+		}
+	}
+
+	maxElems := Settings.MaxElems
+	if fullDump {
+		maxElems = 0
+	}
+
+	writeSection := func(title string, kvs []kv, write func(kv kv)) {
+		if len(kvs) == 0 {
+			return
+		}
+
+		b.WriteString(prefix)
+		b.WriteString(title)
+		b.WriteString(":\n")
+
+		n := len(kvs)
+		if maxElems > 0 {
+			n = min(n, maxElems)
+		}
+
+		for _, kv := range kvs[:n] {
+			write(kv)
+		}
+
+		if more := len(kvs) - n; more > 0 {
+			b.WriteString(prefix)
+			b.WriteString(dumpIndent)
+			b.WriteString("... ")
+			b.WriteString(fmtCount(more))
+			b.WriteString(" more entries\n")
+		}
+	}
+
+	writeEntry := func(kv kv) {
+		d := st.newDumper(0)
+		d.walkCirculars(kv.k)
+		d.walkCirculars(kv.v)
+		d.fmtVal(kv.k)
+		d.buf.WriteString(": ")
+		d.fmtVal(kv.v)
+		d.buf.WriteByte(',')
+
+		b.WriteString(textwrap.IndentFunc(
+			d.buf.String()+"\n",
+			prefix+dumpIndent,
+			func(string) bool { return true }))
+	}
+
+	writeSection("Only in got", onlyG, writeEntry)
+	writeSection("Only in want", onlyE, writeEntry)
+	writeSection("Different values", changed, func(kv kv) {
+		b.WriteString(textwrap.IndentFunc(
+			st.dump(kv.k.Interface(), 0)+":\n",
+			prefix+dumpIndent,
+			func(string) bool { return true }))
+
+		writeValueDiff(
+			b,
+			kv.v.Interface(),
+			ev.MapIndex(kv.k).Interface(),
+			prefix+dumpIndent+dumpIndent,
+			st)
+	})
+
+	if numSame > 0 {
+		b.WriteString(prefix)
+		b.WriteString("... ")
+		b.WriteString(fmtCount(numSame))
+		b.WriteString(" identical entries ...\n")
+	}
+}
+
+// Max size of the LCS table for slice diffs before falling back to a line
+// diff
+const maxSliceDiffCells = 1 << 18
+
+type sliceOpType int8
+
+const (
+	sliceSame sliceOpType = iota
+	sliceChanged
+	sliceDelete
+	sliceInsert
+)
+
+type sliceOp struct {
+	typ    sliceOpType
+	gi, ei int
+}
+
+// writeSliceDiff diffs two slices or arrays element-by-element, so that parts
+// of different elements can't be paired together. It returns false if the
+// slices are too big to diff this way.
+func writeSliceDiff(b *strings.Builder, gv, ev reflect.Value, prefix string, st *diffState) bool {
+	var (
+		n, m   = gv.Len(), ev.Len()
+		keyFn  = getSliceKey(gv.Type().Elem())
+		gAt    = func(i int) any { return gv.Index(i).Interface() }
+		eAt    = func(i int) any { return ev.Index(i).Interface() }
+		same   = func(i, j int) bool { return deepEqual(gAt(i), eAt(j)) }
+		paired = same
+	)
+
+	if keyFn != nil {
+		paired = func(i, j int) bool {
+			return keyFn(gv.Index(i)) == keyFn(ev.Index(j))
+		}
+	}
+
+	pre := 0
+	for pre < n && pre < m && paired(pre, pre) {
+		pre++
+	}
+
+	suf := 0
+	for suf < n-pre && suf < m-pre && paired(n-1-suf, m-1-suf) {
+		suf++
+	}
+
+	if (n-pre-suf)*(m-pre-suf) > maxSliceDiffCells {
+		return false
+	}
+
+	var matches [][2]int
+	for i := range pre {
+		matches = append(matches, [2]int{i, i})
+	}
+
+	mid := lcs(n-pre-suf, m-pre-suf, func(i, j int) bool {
+		return paired(pre+i, pre+j)
+	})
+	for _, match := range mid {
+		matches = append(matches, [2]int{pre + match[0], pre + match[1]})
+	}
+
+	for i := range suf {
+		matches = append(matches, [2]int{n - suf + i, m - suf + i})
+	}
+
+	var (
+		ops    []sliceOp
+		gi, ei int
+	)
+
+	addGap := func(gEnd, eEnd int) {
+		// Without keys, there's no way to know which elements correspond, so
+		// assume that elements in the same position were changed.
+		if keyFn == nil {
+			for gi < gEnd && ei < eEnd {
+				ops = append(ops, sliceOp{sliceChanged, gi, ei})
+				gi++
+				ei++
+			}
+		}
+
+		for ; gi < gEnd; gi++ {
+			ops = append(ops, sliceOp{sliceDelete, gi, -1})
+		}
+
+		for ; ei < eEnd; ei++ {
+			ops = append(ops, sliceOp{sliceInsert, -1, ei})
+		}
+	}
+
+	for _, match := range matches {
+		addGap(match[0], match[1])
+
+		typ := sliceSame
+		if keyFn != nil && !same(gi, ei) {
+			typ = sliceChanged
+		}
+
+		ops = append(ops, sliceOp{typ, gi, ei})
+		gi++
+		ei++
+	}
+
+	addGap(n, m)
+
+	maxElems := Settings.MaxElems
+	if fullDump {
+		maxElems = 0
+	}
+
+	writeElem := func(sign string, label string, v any) {
+		lines := strings.Split(st.dump(v, 0), "\n")
+		for i, line := range lines {
+			b.WriteString(prefix)
+			b.WriteString(sign)
+
+			if i == 0 {
+				b.WriteString(label)
+				b.WriteString(": ")
+			}
+
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+
+	shown := 0
+	for i := 0; i < len(ops); i++ {
+		op := ops[i]
+
+		if op.typ == sliceSame {
+			start := i
+			for i+1 < len(ops) && ops[i+1].typ == sliceSame {
+				i++
+			}
+
+			b.WriteString(prefix)
+			b.WriteString("... ")
+			b.WriteString(fmtCount(i - start + 1))
+			b.WriteString(" identical elements ...\n")
+			continue
+		}
+
+		if maxElems > 0 && shown == maxElems {
+			more := 0
+			for _, op := range ops[i:] {
+				if op.typ != sliceSame {
+					more++
+				}
+			}
+
+			b.WriteString(prefix)
+			b.WriteString("... ")
+			b.WriteString(fmtCount(more))
+			b.WriteString(" more elements\n")
+			break
+		}
+
+		shown++
+
+		switch op.typ {
+		case sliceDelete:
+			writeElem("- ", "got["+strconv.Itoa(op.gi)+"]", gAt(op.gi))
+		case sliceInsert:
+			writeElem("+ ", "want["+strconv.Itoa(op.ei)+"]", eAt(op.ei))
+		case sliceChanged:
+			b.WriteString(prefix)
+			b.WriteString("~ got[")
+			b.WriteString(strconv.Itoa(op.gi))
+			b.WriteString("], want[")
+			b.WriteString(strconv.Itoa(op.ei))
+			b.WriteString("]:\n")
+			writeValueDiff(b, gAt(op.gi), eAt(op.ei), prefix+dumpIndent, st)
+		}
+	}
+
+	return true
+}
+
+// lcs finds the longest common subsequence of two sequences of length n and m,
+// returning the index pairs of the matches.
+func lcs(n, m int, eq func(i, j int) bool) [][2]int {
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if eq(i, j) {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+
+	var (
+		ret  = make([][2]int, 0, table[0][0])
+		i, j int
+	)
+
+	for i < n && j < m {
+		switch {
+		case table[i][j] == table[i+1][j+1]+1 && eq(i, j):
+			ret = append(ret, [2]int{i, j})
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return ret
+}
+
+var sliceKeys struct {
+	mtx sync.RWMutex
+	fns map[reflect.Type]func(reflect.Value) any
+}
+
+// RegisterSliceKey sets the function used to identify elements of type T when
+// diffing slices. Elements with equal keys are treated as the same element,
+// so a changed element is shown as a nested diff instead of as a deletion and
+// an insertion. Without a key, elements are matched as in [Equal].
+func RegisterSliceKey[T any, K comparable](fn func(T) K) {
+	sliceKeys.mtx.Lock()
+	defer sliceKeys.mtx.Unlock()
+
+	if sliceKeys.fns == nil {
+		sliceKeys.fns = make(map[reflect.Type]func(reflect.Value) any)
+	}
+
+	sliceKeys.fns[reflect.TypeFor[T]()] = func(rv reflect.Value) any {
+		return fn(rv.Interface().(T))
+	}
+}
+
+func getSliceKey(rt reflect.Type) func(reflect.Value) any {
+	sliceKeys.mtx.RLock()
+	defer sliceKeys.mtx.RUnlock()
+
+	return sliceKeys.fns[rt]
+}
+
+// writeDiff writes the given diff, folding runs of unchanged lines that are
+// more than context lines away from a change.
+func writeDiff(b *strings.Builder, diffs []diffLine, context int, prefix string) {
+	keep := keepLines(diffs, context)
+
+	writeLine := func(s ...string) {
+		b.WriteString(prefix)
+		for _, s := range s {
+			b.WriteString(s)
+		}
+		b.WriteByte('\n')
+	}
+
+	for i := 0; i < len(diffs); {
+		if !keep[i] {
+			start := i
+			for i < len(diffs) && !keep[i] {
+				i++
+			}
+
+			writeLine("... ", fmtCount(i-start), " identical lines ...")
+			continue
+		}
+
+		end := i
+		for end < len(diffs) && keep[end] {
+			end++
+		}
+
+		if i > 0 {
+			if header := hunkHeader(diffs[i:end]); header != "" {
+				writeLine("@@ ", header, " @@")
+			}
+		}
+
+		for _, diff := range diffs[i:end] {
+			writeLine(diff.String())
+		}
+
+		i = end
+	}
+}
+
+// keepLines determines which lines are within context of a change; the rest
+// are folded away.
+func keepLines(diffs []diffLine, context int) []bool {
+	keep := make([]bool, len(diffs))
+	for i, diff := range diffs {
+		if context < 0 {
+			keep[i] = true
+			continue
+		}
+
+		if diff.typ == patience.Equal {
+			continue
+		}
+
+		lo := max(i-context, 0)
+		hi := min(i+context+1, len(diffs))
+		for j := lo; j < hi; j++ {
+			keep[j] = true
+		}
+	}
+
+	// Folding a single line just replaces it with a marker
+	for i := range keep {
+		if keep[i] {
+			continue
+		}
+
+		prevKeep := i == 0 || keep[i-1]
+		nextKeep := i == len(keep)-1 || keep[i+1]
+		if prevKeep && nextKeep {
+			keep[i] = true
+		}
+	}
+
+	return keep
+}
+
+// hunkHeader finds the deepest path that encloses every change in a hunk
+func hunkHeader(diffs []diffLine) string {
+	var (
+		prefix []string
+		found  bool
+	)
+
+	for _, diff := range diffs {
+		if diff.typ == patience.Equal {
+			continue
+		}
+
+		if !found {
+			prefix = diff.path
+			found = true
+			continue
+		}
+
+		n := 0
+		for n < len(prefix) && n < len(diff.path) && prefix[n] == diff.path[n] {
+			n++
+		}
+
+		prefix = prefix[:n]
+	}
+
+	return strings.Join(prefix, "")
+}
@@ -0,0 +1,321 @@
+package check
+
+import (
+	"strings"
+	"testing"
+)
+
+func testDiff(g, e any, context int) string {
+	var b strings.Builder
+	writeDiff(&b, diffDumps(dumpLines(g), dumpLines(e)), context, dumpIndent)
+	return b.String()
+}
+
+func TestDumpLinesPaths(t *testing.T) {
+	type inner struct {
+		A int
+	}
+
+	d := dumpLines(struct {
+		S []inner
+		M map[string]int
+	}{
+		S: []inner{{A: 1}},
+		M: map[string]int{"k": 1},
+	})
+
+	Equal(t, len(d.lines), len(d.paths))
+
+	got := make([]string, len(d.paths))
+	for i, path := range d.paths {
+		got[i] = strings.Join(path, "")
+	}
+
+	Equal(t, got, []string{
+		"",
+		".S",
+		".S[0]",
+		".S[0].A",
+		".S[0]",
+		".S",
+		".M",
+		`.M["k"]`,
+		".M",
+		"",
+	})
+}
+
+func TestDiffFolding(t *testing.T) {
+	var (
+		g = make([]int, 20)
+		e = make([]int, 20)
+	)
+
+	e[10] = 1
+
+	Equal(
+		t,
+		testDiff(g, e, 2),
+		dumpIndent+"... 9 identical lines ...\n"+
+			dumpIndent+"@@ [10] @@\n"+
+			dumpIndent+"      int(0),\n"+
+			dumpIndent+"      int(0),\n"+
+			dumpIndent+"-     int(0),\n"+
+			dumpIndent+"+     int(1),\n"+
+			dumpIndent+"      int(0),\n"+
+			dumpIndent+"      int(0),\n"+
+			dumpIndent+"... 8 identical lines ...\n",
+	)
+
+	t.Run("Disabled", func(t *testing.T) {
+		diff := testDiff(g, e, -1)
+		Equal(t, strings.Count(diff, "\n"), 23)
+		NotContains(t, diff, "identical lines")
+	})
+
+	t.Run("SingleLineGap", func(t *testing.T) {
+		e := make([]int, 20)
+		e[10] = 1
+		e[13] = 1
+
+		diff := testDiff(g, e, 1)
+		Equal(t, strings.Count(diff, "identical lines"), 2)
+	})
+}
+
+func TestDiffBeyondLimits(t *testing.T) {
+	t.Run("Slice", func(t *testing.T) {
+		var g, e []int
+		for i := range 200 {
+			g = append(g, i)
+			e = append(e, i)
+		}
+
+		e[150] = -1
+
+		msg := equalMsg(g, e)
+		Contains(t, msg, "-     int(150),\n")
+		Contains(t, msg, "+     int(-1),\n")
+		NotContains(t, msg, "dumps are identical")
+	})
+
+	t.Run("String", func(t *testing.T) {
+		var (
+			g = strings.Repeat("a", 1999) + "b"
+			e = strings.Repeat("a", 1999) + "c"
+		)
+
+		msg := equalMsg(g, e)
+		Contains(t, msg, "Expected: /* ... */ aaa")
+		Contains(t, msg, `ab"`+"\n")
+		Contains(t, msg, `ac"`)
+		True(t, len(msg) < 2*(Settings.MaxLen+100))
+	})
+
+	t.Run("Bytes", func(t *testing.T) {
+		var (
+			g = make([]byte, 2000)
+			e = make([]byte, 2000)
+		)
+
+		e[1500] = 0xff
+
+		msg := equalMsg(g, e)
+		Contains(t, msg, "+     000005d0: 0000 0000 0000 0000 0000 0000 ff00 0000")
+		NotContains(t, msg, "dumps are identical")
+	})
+}
+
+func TestHunkHeader(t *testing.T) {
+	type inner struct {
+		A, B, C, D, E, F, G, H int
+	}
+
+	var (
+		g = map[string]inner{"k": {}}
+		e = map[string]inner{"k": {B: 1, F: 1}}
+	)
+
+	diff := testDiff(g, e, 1)
+	Contains(t, diff, `@@ ["k"] @@`)
+
+	e = map[string]inner{"k": {F: 1}}
+	diff = testDiff(g, e, 1)
+	Contains(t, diff, `@@ ["k"].F @@`)
+}
+
+func TestFmtCount(t *testing.T) {
+	Equal(t, fmtCount(0), "0")
+	Equal(t, fmtCount(999), "999")
+	Equal(t, fmtCount(99_990), "99,990")
+	Equal(t, fmtCount(-1_234_567), "-1,234,567")
+}
+
+func TestMapDiff(t *testing.T) {
+	var (
+		g = map[string]int{"same": 0, "got": 1, "diff": 2}
+		e = map[string]int{"same": 0, "want": 1, "diff": 3}
+	)
+
+	Equal(
+		t,
+		equalMsg(g, e),
+		"Expected values to be equal:\n"+
+			dumpIndent+"Only in got:\n"+
+			dumpIndent+dumpIndent+`"got": int(1),`+"\n"+
+			dumpIndent+"Only in want:\n"+
+			dumpIndent+dumpIndent+`"want": int(1),`+"\n"+
+			dumpIndent+"Different values:\n"+
+			dumpIndent+dumpIndent+`"diff":`+"\n"+
+			dumpIndent+dumpIndent+dumpIndent+"- int(2)\n"+
+			dumpIndent+dumpIndent+dumpIndent+"+ int(3)\n"+
+			dumpIndent+"... 1 identical entries ...",
+	)
+
+	t.Run("Nested", func(t *testing.T) {
+		var (
+			g = map[int]map[int]int{1: {1: 1}}
+			e = map[int]map[int]int{1: {1: 2}}
+		)
+
+		Equal(
+			t,
+			equalMsg(g, e),
+			"Expected values to be equal:\n"+
+				dumpIndent+"Different values:\n"+
+				dumpIndent+dumpIndent+"int(1):\n"+
+				dumpIndent+dumpIndent+dumpIndent+"Different values:\n"+
+				dumpIndent+dumpIndent+dumpIndent+dumpIndent+"int(1):\n"+
+				dumpIndent+dumpIndent+dumpIndent+dumpIndent+dumpIndent+"- int(1)\n"+
+				dumpIndent+dumpIndent+dumpIndent+dumpIndent+dumpIndent+"+ int(2)",
+		)
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.MaxElems = 1 })
+
+		msg := equalMsg(map[int]int{1: 1, 2: 2, 3: 3}, map[int]int{})
+		Contains(t, msg, "... 2 more entries")
+	})
+
+	t.Run("NilMap", func(t *testing.T) {
+		msg := equalMsg(map[int]int{1: 1}, map[int]int(nil))
+		NotContains(t, msg, "Only in got")
+	})
+
+	t.Run("Circular", func(t *testing.T) {
+		g := map[string]any{"x": 1}
+		g["self"] = g
+		e := map[string]any{"x": 2}
+		e["self"] = e
+
+		msg := equalMsg(g, e)
+		Contains(t, msg, "Different values:")
+		Contains(t, msg, "/* 0x1 */")
+	})
+}
+
+type testSliceElem struct {
+	ID   int
+	Name string
+}
+
+type testKeyedElem testSliceElem
+
+func init() {
+	RegisterSliceKey(func(v testKeyedElem) int { return v.ID })
+}
+
+func TestSliceDiff(t *testing.T) {
+	t.Run("Positional", func(t *testing.T) {
+		var (
+			g = []testSliceElem{{1, "a"}, {2, "b"}, {3, "c"}}
+			e = []testSliceElem{{1, "a"}, {2, "B"}, {3, "c"}, {4, "d"}}
+		)
+
+		Equal(
+			t,
+			equalMsg(g, e),
+			"Expected values to be equal:\n"+
+				dumpIndent+"... 1 identical elements ...\n"+
+				dumpIndent+"~ got[1], want[1]:\n"+
+				dumpIndent+dumpIndent+"  check.testSliceElem{\n"+
+				dumpIndent+dumpIndent+"      ID: int(2),\n"+
+				dumpIndent+dumpIndent+`-     Name: "b",`+"\n"+
+				dumpIndent+dumpIndent+`+     Name: "B",`+"\n"+
+				dumpIndent+dumpIndent+"  }\n"+
+				dumpIndent+"... 1 identical elements ...\n"+
+				dumpIndent+"+ want[3]: check.testSliceElem{\n"+
+				dumpIndent+"+     ID: int(4),\n"+
+				dumpIndent+`+     Name: "d",`+"\n"+
+				dumpIndent+"+ }",
+		)
+	})
+
+	t.Run("Keyed", func(t *testing.T) {
+		var (
+			g = []testKeyedElem{{1, "a"}, {2, "b"}, {3, "c"}}
+			e = []testKeyedElem{{1, "a"}, {3, "C"}}
+		)
+
+		msg := equalMsg(g, e)
+		Contains(t, msg, "- got[1]: check.testKeyedElem{")
+		Contains(t, msg, "~ got[2], want[1]:")
+	})
+
+	t.Run("Arrays", func(t *testing.T) {
+		var (
+			g = [2]*int{nil, new(int)}
+			e = [2]*int{nil, nil}
+		)
+
+		Contains(t, equalMsg(g, e), "~ got[1], want[1]:")
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.MaxElems = 1 })
+
+		var (
+			g = []*int{}
+			e = []*int{nil, nil, nil}
+		)
+
+		Contains(t, equalMsg(g, e), "... 2 more elements")
+	})
+
+	t.Run("Circular", func(t *testing.T) {
+		g := []any{1, nil}
+		g[1] = g
+		e := []any{2, nil}
+		e[1] = e
+
+		msg := equalMsg(g, e)
+		Contains(t, msg, "~ got[0], want[0]:")
+		Contains(t, msg, "/* 0x1 */")
+	})
+
+	t.Run("TooBig", func(t *testing.T) {
+		var (
+			g = make([][]int, 1024)
+			e = make([][]int, 1024)
+		)
+
+		for i := range g {
+			g[i] = []int{i}
+			e[i] = []int{-i - 1}
+		}
+
+		NotContains(t, equalMsg(g, e), "~ got[")
+	})
+}
+
+func TestLCS(t *testing.T) {
+	lcsStrs := func(a, b string) [][2]int {
+		return lcs(len(a), len(b), func(i, j int) bool { return a[i] == b[j] })
+	}
+
+	Equal(t, lcsStrs("", "abc"), [][2]int{})
+	Equal(t, lcsStrs("abc", "abc"), [][2]int{{0, 0}, {1, 1}, {2, 2}})
+	Equal(t, lcsStrs("abcd", "acd"), [][2]int{{0, 0}, {2, 1}, {3, 2}})
+	Equal(t, lcsStrs("xaby", "abz"), [][2]int{{1, 0}, {2, 1}})
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 	"unsafe"
+
+	"github.com/thatguystone/cog/callstack"
 )
 
 type circularKey struct {
@@ -37,19 +41,94 @@ type dumper struct {
 	indentDepth int
 	seen        map[circularKey]struct{}
 	ids         map[circularKey]int
+
+	// Formatting options from Config
+	hexdumpMin     int
+	bytesAsText    bool
+	hideUnexported bool
+
+	// Stable ids for addresses, in walk order, when deterministic
+	deterministic bool
+	addrIDs       map[uint64]int
+
+	// Limits from Config; 0 means unlimited
+	depth     int
+	maxDepth  int
+	maxElems  int
+	maxLen    int
+	truncated bool
+
+	// Only tracked when paths != nil: the path to the value currently being
+	// formatted, and the path of each line written so far.
+	path  []string
+	paths [][]string
 }
 
-func dump(v any, initialIndent int) string {
-	d := dumper{
-		indentDepth: initialIndent,
-		seen:        make(map[circularKey]struct{}),
-		ids:         make(map[circularKey]int),
+func newDumper(initialIndent int) *dumper {
+	d := &dumper{
+		indentDepth:    initialIndent,
+		seen:           make(map[circularKey]struct{}),
+		ids:            make(map[circularKey]int),
+		hexdumpMin:     Settings.HexdumpMin,
+		bytesAsText:    Settings.BytesAsText,
+		hideUnexported: Settings.HideUnexported,
+		deterministic:  Settings.Deterministic,
+		addrIDs:        make(map[uint64]int),
+	}
+
+	if !fullDump {
+		d.maxDepth = Settings.MaxDepth
+		d.maxElems = Settings.MaxElems
+		d.maxLen = Settings.MaxLen
 	}
 
-	if initialIndent > 0 {
+	return d
+}
+
+// Dump formats v the same way values are shown in failure messages, using the
+// current [Settings]. With [Config.Deterministic], the output is stable across
+// runs, making it suitable for golden files.
+func Dump(v any) string {
+	return dump(v, 0)
+}
+
+func dump(v any, initialIndent int) string {
+	return newDumper(initialIndent).dumpString(v)
+}
+
+func (d *dumper) dumpString(v any) string {
+	if d.indentDepth > 0 {
 		d.writeIndent()
 	}
 
+	d.dump(v)
+	return d.buf.String()
+}
+
+type dumpedLines struct {
+	lines     []string
+	paths     [][]string // Path of the value that each line belongs to
+	truncated bool       // If any limits were hit
+}
+
+// dumpLines dumps v and splits the result into lines
+func dumpLines(v any) dumpedLines {
+	return newDumper(0).dumpLines(v)
+}
+
+func (d *dumper) dumpLines(v any) dumpedLines {
+	d.paths = [][]string{nil} // The first line doesn't start with an indent
+
+	d.dump(v)
+
+	return dumpedLines{
+		lines:     strings.Split(d.buf.String(), "\n"),
+		paths:     d.paths,
+		truncated: d.truncated,
+	}
+}
+
+func (d *dumper) dump(v any) {
 	if v == nil {
 		d.buf.WriteString("nil")
 	} else {
@@ -57,8 +136,6 @@ func dump(v any, initialIndent int) string {
 		d.walkCirculars(rv)
 		d.fmtVal(rv)
 	}
-
-	return d.buf.String()
 }
 
 const (
@@ -117,12 +194,26 @@ func (d *dumper) walkCirculars(rv reflect.Value) {
 			}
 		}
 	case reflect.Struct:
-		for i := range rv.NumField() {
+		for _, i := range d.visibleFields(rv.Type()) {
 			d.walkCirculars(rv.Field(i))
 		}
 	}
 }
 
+// visibleFields gets the indexes of the fields of rt that should be dumped
+func (d *dumper) visibleFields(rt reflect.Type) []int {
+	fields := make([]int, 0, rt.NumField())
+	for i := range rt.NumField() {
+		if d.hideUnexported && !rt.Field(i).IsExported() {
+			continue
+		}
+
+		fields = append(fields, i)
+	}
+
+	return fields
+}
+
 func (d *dumper) fmtVal(rv reflect.Value) {
 	key, ok := makeCircularKey(rv)
 	if ok {
@@ -149,8 +240,25 @@ func (d *dumper) fmtVal(rv reflect.Value) {
 		defer delete(d.seen, key)
 	}
 
+	if d.fmtCustom(rv) {
+		return
+	}
+
 	d.writeAnnotation(rv)
 
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Struct:
+		if d.maxDepth > 0 && d.depth >= d.maxDepth {
+			d.writeType(rv)
+			d.buf.WriteString("{...}")
+			d.truncated = true
+			return
+		}
+
+		d.depth++
+		defer func() { d.depth-- }()
+	}
+
 	switch rv.Kind() {
 	case reflect.Bool:
 		d.fmtBool(rv)
@@ -271,7 +379,7 @@ func (d *dumper) fmtString(rv reflect.Value) {
 		d.buf.WriteByte('(')
 	}
 
-	d.writeGoString(rv.String())
+	d.writeString(rv.String())
 
 	if hasType {
 		d.buf.WriteByte(')')
@@ -293,50 +401,167 @@ func (d *dumper) fmtSlice(rv reflect.Value) {
 		}
 	}
 
+	if rv.Type().Elem() == reflect.TypeOf(byte(0)) {
+		d.fmtBytes(rv)
+		return
+	}
+
 	d.buf.WriteString("{")
 	d.indent()
 
-	if rv.Type().Elem() == reflect.TypeOf(byte(0)) {
-		var (
-			n            = rv.Len()
-			nlines       = (n / 8) + 1
-			lineOverhead = (len(dumpIndent) * d.indentDepth) + 1 // indent + nl
-		)
+	n := d.limit(rv.Len(), d.maxElems)
+
+	d.buf.WriteString("\n")
+	for i := range n {
+		d.pushPath(i)
+		d.writeIndent()
+		d.fmtVal(rv.Index(i))
+		d.buf.WriteString(",\n")
+		d.popPath()
+	}
+
+	d.writeMoreLine(rv.Len()-n, "elements")
+
+	d.dedent()
+	d.writeIndent()
+	d.buf.WriteByte('}')
+}
+
+func (d *dumper) fmtBytes(rv reflect.Value) {
+	var (
+		total = rv.Len()
+		n     = d.limit(total, d.maxLen)
+		b     = leadingBytes(rv, n)
+	)
+
+	if d.bytesAsText && rv.Kind() == reflect.Slice {
+		if str, ok := textPrefix(b, n < total); ok {
+			d.buf.WriteByte('(')
+			d.writeTruncatedString(str, total-len(str))
+			d.buf.WriteByte(')')
+			return
+		}
+	}
+
+	d.buf.WriteString("{")
+	d.indent()
+
+	if d.hexdumpMin > 0 && total >= d.hexdumpMin {
+		d.writeHexdump(b)
+	} else {
+		d.writeByteList(b)
+	}
+
+	d.writeMoreLine(total-n, "bytes")
+
+	d.dedent()
+	d.writeIndent()
+	d.buf.WriteByte('}')
+}
 
-		d.buf.Grow((n * len("0x00, ")) + (nlines * lineOverhead))
+// writeByteList writes bytes as Go literals, 8 per line
+func (d *dumper) writeByteList(bs []byte) {
+	var (
+		n            = len(bs)
+		nlines       = (n / 8) + 1
+		lineOverhead = (len(dumpIndent) * d.indentDepth) + 1 // indent + nl
+	)
+
+	d.buf.Grow((n * len("0x00, ")) + (nlines * lineOverhead))
+
+	for i, v := range bs {
+		if i%8 == 0 {
+			d.buf.WriteString("\n")
+			d.pushPath(i)
+			d.writeIndent()
+			d.popPath()
+		} else {
+			d.buf.WriteByte(' ')
+		}
+
+		b := d.buf.AvailableBuffer()
+		b = append(b, "0x"...)
+		if v < 0x10 {
+			b = append(b, '0')
+		}
+		b = strconv.AppendUint(b, uint64(v), 16)
+		b = append(b, ',')
+		d.buf.Write(b)
+	}
+
+	d.buf.WriteString("\n")
+}
+
+// writeHexdump writes bytes like `xxd`, 16 per line with an offset column and
+// an ASCII gutter. Runs of repeated lines are collapsed into a single "*".
+func (d *dumper) writeHexdump(bs []byte) {
+	const (
+		width   = 16
+		hexCols = (width * 2) + (width / 2) - 1 // "0001 0203 ..."
+	)
 
-		for i := range n {
-			if i%8 == 0 {
+	var (
+		prev      []byte
+		collapsed bool
+	)
+
+	for off := 0; off < len(bs); off += width {
+		line := bs[off:min(off+width, len(bs))]
+
+		d.pushPath(off)
+
+		// Always show the last line so the end offset is visible
+		isLast := off+width >= len(bs)
+		if !isLast && bytes.Equal(line, prev) {
+			if !collapsed {
 				d.buf.WriteString("\n")
 				d.writeIndent()
-			} else {
-				d.buf.WriteByte(' ')
+				d.buf.WriteByte('*')
+				collapsed = true
 			}
 
-			v := rv.Index(i).Uint()
-			b := d.buf.AvailableBuffer()
-			b = append(b, "0x"...)
-			if v < 0x10 {
-				b = append(b, '0')
-			}
-			b = strconv.AppendUint(b, v, 16)
-			b = append(b, ',')
-			d.buf.Write(b)
+			d.popPath()
+			continue
 		}
 
+		prev = line
+		collapsed = false
+
 		d.buf.WriteString("\n")
-	} else {
-		d.buf.WriteString("\n")
-		for i := range rv.Len() {
-			d.writeIndent()
-			d.fmtVal(rv.Index(i))
-			d.buf.WriteString(",\n")
+		d.writeIndent()
+		d.popPath()
+
+		b := d.buf.AvailableBuffer()
+		b = fmt.Appendf(b, "%08x: ", off)
+
+		cols := 0
+		for i, v := range line {
+			if i > 0 && i%2 == 0 {
+				b = append(b, ' ')
+				cols++
+			}
+
+			b = fmt.Appendf(b, "%02x", v)
+			cols += 2
+		}
+
+		for ; cols < hexCols; cols++ {
+			b = append(b, ' ')
+		}
+
+		b = append(b, "  "...)
+		for _, v := range line {
+			if v < ' ' || v > '~' {
+				v = '.'
+			}
+
+			b = append(b, v)
 		}
+
+		d.buf.Write(b)
 	}
 
-	d.dedent()
-	d.writeIndent()
-	d.buf.WriteByte('}')
+	d.buf.WriteString("\n")
 }
 
 func (d *dumper) fmtMap(rv reflect.Value) {
@@ -355,14 +580,21 @@ func (d *dumper) fmtMap(rv reflect.Value) {
 	d.buf.WriteString("{\n")
 	d.indent()
 
-	for _, kv := range sortMap(rv) {
+	kvs := sortMap(rv)
+	n := d.limit(len(kvs), d.maxElems)
+
+	for _, kv := range kvs[:n] {
+		d.pushPath(kv.k)
 		d.writeIndent()
 		d.fmtVal(kv.k)
 		d.buf.WriteString(": ")
 		d.fmtVal(kv.v)
 		d.buf.WriteString(",\n")
+		d.popPath()
 	}
 
+	d.writeMoreLine(len(kvs)-n, "entries")
+
 	d.dedent()
 	d.writeIndent()
 	d.buf.WriteByte('}')
@@ -372,11 +604,11 @@ func (d *dumper) fmtStruct(rv reflect.Value) {
 	d.writeType(rv)
 
 	var (
-		rt       = rv.Type()
-		numField = rt.NumField()
+		rt     = rv.Type()
+		fields = d.visibleFields(rt)
 	)
 
-	if numField == 0 {
+	if len(fields) == 0 {
 		d.buf.WriteString("{}")
 		return
 	}
@@ -384,12 +616,16 @@ func (d *dumper) fmtStruct(rv reflect.Value) {
 	d.buf.WriteString("{\n")
 	d.indent()
 
-	for i := range numField {
+	for _, i := range fields {
+		name := rt.Field(i).Name
+
+		d.pushPath(name)
 		d.writeIndent()
-		d.buf.WriteString(rt.Field(i).Name)
+		d.buf.WriteString(name)
 		d.buf.WriteString(": ")
 		d.fmtVal(rv.Field(i))
 		d.buf.WriteString(",\n")
+		d.popPath()
 	}
 
 	d.dedent()
@@ -445,19 +681,63 @@ func (d *dumper) fmtOpaquePointer(rv reflect.Value) {
 		ptr = uint64(rv.Pointer())
 	}
 
-	if ptr == 0 {
+	switch {
+	case ptr == 0:
 		d.buf.WriteString("nil")
-	} else {
-		d.buf.Grow(maxBase16Len)
-		b := d.buf.AvailableBuffer()
-		b = append(b, "0x"...)
-		b = strconv.AppendUint(b, ptr, 16)
-		d.buf.Write(b)
+	case rv.Kind() == reflect.Func:
+		fr := callstack.PC(ptr).Frame()
+		d.buf.WriteString(fr.Func())
+		fmt.Fprintf(&d.buf, " /* %s:%d */", fr.FileName(), fr.Line())
+	case rv.Kind() == reflect.Uintptr:
+		d.writeHex(ptr)
+	default:
+		d.writeAddr(ptr)
 	}
 
 	d.buf.WriteByte(')')
 }
 
+// writeAddr writes an address, or its id when deterministic
+func (d *dumper) writeAddr(ptr uint64) {
+	if !d.deterministic {
+		d.writeHex(ptr)
+		return
+	}
+
+	id, ok := d.addrIDs[ptr]
+	if !ok {
+		// Like circular ids, start at 1 to avoid nil-looking values
+		id = len(d.addrIDs) + 1
+		d.addrIDs[ptr] = id
+	}
+
+	d.buf.WriteByte('#')
+	d.buf.WriteString(strconv.Itoa(id))
+}
+
+func (d *dumper) writeHex(v uint64) {
+	d.buf.Grow(maxBase16Len)
+	b := d.buf.AvailableBuffer()
+	b = append(b, "0x"...)
+	b = strconv.AppendUint(b, v, 16)
+	d.buf.Write(b)
+}
+
+var (
+	errorType    = reflect.TypeFor[error]()
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+)
+
+func implementsAny(rt reflect.Type, ifaces ...reflect.Type) bool {
+	for _, iface := range ifaces {
+		if rt.Implements(iface) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (d *dumper) writeAnnotation(rv reflect.Value) {
 	// Only annotate concrete values: pointers and interfaces all resolve into
 	// concrete types, so annotating them results in printing the same thing
@@ -467,13 +747,15 @@ func (d *dumper) writeAnnotation(rv reflect.Value) {
 		return
 	}
 
-	if !rv.CanInterface() {
-		tmp, ok := forceCanInterface(rv)
-		if !ok {
-			return
-		}
+	rt := rv.Type()
+	if !implementsAny(rt, errorType, stringerType) &&
+		!implementsAny(reflect.PointerTo(rt), errorType, stringerType) {
+		return
+	}
 
-		rv = tmp
+	rv, ok := canInterface(rv)
+	if !ok {
+		return
 	}
 
 	// rv can't be a ptr at this point, but methods might have ptr receivers
@@ -512,6 +794,69 @@ func (d *dumper) writeAnnotation(rv reflect.Value) {
 	d.buf.WriteString(" */")
 }
 
+// writeString writes a string as a Go literal, subject to limits
+func (d *dumper) writeString(str string) {
+	more := 0
+	if d.maxLen > 0 && len(str) > d.maxLen {
+		more = len(str) - d.maxLen
+		str = str[:d.maxLen]
+
+		// Don't split a rune in half
+		for i := 0; i < utf8.UTFMax && !utf8.ValidString(str); i++ {
+			str = str[:len(str)-1]
+			more++
+		}
+	}
+
+	d.writeTruncatedString(str, more)
+}
+
+// writeTruncatedString writes str, noting that more bytes were cut off its end
+func (d *dumper) writeTruncatedString(str string, more int) {
+	multiline := strings.Contains(strings.TrimSuffix(str, "\n"), "\n")
+	if multiline {
+		d.writeMultilineString(str)
+	} else {
+		d.writeGoString(str)
+	}
+
+	switch {
+	case more > 0:
+		d.buf.WriteString(" /* ")
+		d.writeMore(more, "bytes")
+		d.buf.WriteString(" */")
+	case multiline && !strings.HasSuffix(str, "\n"):
+		// Make trailing newline differences obvious in diffs
+		d.buf.WriteString(" /* no newline at end */")
+	}
+}
+
+// writeMultilineString writes v as a concatenation of one quoted string per
+// line, so that diffs line up with the lines of v.
+func (d *dumper) writeMultilineString(v string) {
+	d.buf.WriteString(`""+`)
+	d.indent()
+
+	for v != "" {
+		line, rest, found := strings.Cut(v, "\n")
+		if found {
+			line += "\n"
+		}
+
+		d.buf.WriteByte('\n')
+		d.writeIndent()
+		d.writeGoString(line)
+
+		if rest != "" {
+			d.buf.WriteByte('+')
+		}
+
+		v = rest
+	}
+
+	d.dedent()
+}
+
 func (d *dumper) writeGoString(v string) {
 	d.buf.Grow(1 + len(v) + 1)
 
@@ -532,10 +877,15 @@ func (d *dumper) writeGoString(v string) {
 }
 
 func (d *dumper) writeType(rv reflect.Value) {
-	name := rv.Type().String()
+	d.buf.WriteString(typeName(rv.Type()))
+}
+
+// typeName gets the name of rt as it appears in dumps
+func typeName(rt reflect.Type) string {
+	name := rt.String()
 	name = strings.ReplaceAll(name, "interface {}", "any")
 	name = strings.ReplaceAll(name, "interface{}", "any")
-	d.buf.WriteString(name)
+	return name
 }
 
 func (d *dumper) writeFloat(v float64, ensureDot bool) {
@@ -564,13 +914,143 @@ func (d *dumper) dedent() {
 	d.indentDepth--
 }
 
+// limit caps n to max, if set, noting when any truncation happens
+func (d *dumper) limit(n, max int) int {
+	if max > 0 && n > max {
+		d.truncated = true
+		return max
+	}
+
+	return n
+}
+
+// writeMore writes a marker for n elided things
+func (d *dumper) writeMore(n int, what string) {
+	d.truncated = true
+	d.buf.WriteString("... ")
+	d.buf.WriteString(fmtCount(n))
+	d.buf.WriteString(" more ")
+	d.buf.WriteString(what)
+}
+
+// writeMoreLine writes a marker for n elided things on its own line
+func (d *dumper) writeMoreLine(n int, what string) {
+	if n <= 0 {
+		return
+	}
+
+	d.writeIndent()
+	d.writeMore(n, what)
+	d.buf.WriteByte('\n')
+}
+
+// pushPath descends into a struct field (string), slice index (int), or map
+// key (reflect.Value).
+func (d *dumper) pushPath(elem any) {
+	if d.paths == nil {
+		return
+	}
+
+	var seg string
+	switch elem := elem.(type) {
+	case string:
+		seg = "." + elem
+	case int:
+		seg = "[" + strconv.Itoa(elem) + "]"
+	case reflect.Value:
+		seg = "[" + pathKey(elem) + "]"
+	}
+
+	d.path = append(d.path, seg)
+}
+
+func (d *dumper) popPath() {
+	if d.paths == nil {
+		return
+	}
+
+	d.path = d.path[:len(d.path)-1]
+}
+
 func (d *dumper) writeIndent() {
+	if d.paths != nil {
+		d.paths = append(d.paths, slices.Clone(d.path))
+	}
+
 	d.buf.Grow(len(dumpIndent) * d.indentDepth)
 	for range d.indentDepth {
 		d.buf.WriteString(dumpIndent)
 	}
 }
 
+// leadingBytes gets the first n bytes of a []byte or byte array without
+// touching the rest
+func leadingBytes(rv reflect.Value, n int) []byte {
+	if rv.Kind() == reflect.Slice {
+		return rv.Bytes()[:n]
+	}
+
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(rv.Index(i).Uint())
+	}
+
+	return b
+}
+
+// textPrefix checks if b is text. If b was cut short, a rune split in half at
+// its end is dropped.
+func textPrefix(b []byte, truncated bool) (string, bool) {
+	for i := 0; truncated && i < utf8.UTFMax-1 && len(b) > 0 && !utf8.Valid(b); i++ {
+		b = b[:len(b)-1]
+	}
+
+	if !isText(b) {
+		return "", false
+	}
+
+	return string(b), true
+}
+
+// isText determines if b is printable UTF-8 text
+func isText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+
+	for _, r := range string(b) {
+		switch {
+		case r == '\n', r == '\r', r == '\t':
+		case !unicode.IsPrint(r):
+			return false
+		}
+	}
+
+	return true
+}
+
+// pathKey formats a map key for use in a path
+func pathKey(rv reflect.Value) string {
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case reflect.String:
+		return strconv.Quote(rv.String())
+	case reflect.Interface:
+		if !rv.IsNil() {
+			return pathKey(rv.Elem())
+		}
+	}
+
+	return rv.Type().String()
+}
+
 func fmtBase10(s []byte) []byte {
 	ps := s
 	if ps[0] == '-' {
@@ -598,3 +1078,18 @@ func fmtBase10(s []byte) []byte {
 
 	return ret
 }
+
+// fmtCount formats a count for humans, eg. 12,345
+func fmtCount(n int) string {
+	b := make([]byte, 0, maxBase10Len)
+	b = strconv.AppendInt(b, int64(n), 10)
+	b = fmtBase10(b)
+
+	for i, c := range b {
+		if c == '_' {
+			b[i] = ','
+		}
+	}
+
+	return string(b)
+}
@@ -0,0 +1,126 @@
+package check
+
+import (
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Renderers for well-known types whose internals are meaningless in a dump
+func init() {
+	registerDumper(
+		reflect.TypeFor[time.Time](),
+		"",
+		func(w DumpWriter, rv reflect.Value) {
+			w.WriteString(addrOf[time.Time](rv).Format(time.RFC3339Nano))
+		})
+	registerDumper(
+		reflect.TypeFor[time.Location](),
+		"",
+		func(w DumpWriter, rv reflect.Value) {
+			w.WriteString(addrOf[time.Location](rv).String())
+		})
+	registerDumper(
+		reflect.TypeFor[big.Int](),
+		"",
+		func(w DumpWriter, rv reflect.Value) {
+			w.WriteString(addrOf[big.Int](rv).String())
+		})
+	registerDumper(
+		reflect.TypeFor[net.IP](),
+		"",
+		func(w DumpWriter, rv reflect.Value) {
+			ip := rv.Interface().(net.IP)
+			if ip == nil {
+				w.WriteString("nil")
+			} else {
+				w.WriteString(ip.String())
+			}
+		})
+	registerDumper(
+		reflect.TypeFor[netip.Addr](),
+		"",
+		func(w DumpWriter, rv reflect.Value) {
+			w.WriteString(rv.Interface().(netip.Addr).String())
+		})
+	registerDumper(
+		reflect.TypeFor[netip.Prefix](),
+		"",
+		func(w DumpWriter, rv reflect.Value) {
+			w.WriteString(rv.Interface().(netip.Prefix).String())
+		})
+	registerDumper(
+		reflect.TypeFor[url.URL](),
+		"",
+		func(w DumpWriter, rv reflect.Value) {
+			w.WriteString(addrOf[url.URL](rv).String())
+		})
+	registerDumper(
+		reflect.TypeFor[regexp.Regexp](),
+		"",
+		func(w DumpWriter, rv reflect.Value) {
+			expr := addrOf[regexp.Regexp](rv).String()
+			if strconv.CanBackquote(expr) {
+				w.WriteString("`" + expr + "`")
+			} else {
+				w.WriteString(strconv.Quote(expr))
+			}
+		})
+	registerDumper(
+		reflect.TypeOf(reflect.TypeFor[int]()), // The concrete *reflect.rtype
+		"reflect.Type",
+		func(w DumpWriter, rv reflect.Value) {
+			w.WriteString(typeName(rv.Interface().(reflect.Type)))
+		})
+	registerDumper(
+		reflect.TypeFor[sync.Mutex](),
+		"",
+		func(w DumpWriter, rv reflect.Value) {
+			switch locked, ok := mutexLocked(rv); {
+			case !ok:
+				w.WriteString("?")
+			case locked:
+				w.WriteString("locked")
+			default:
+				w.WriteString("unlocked")
+			}
+		})
+}
+
+// addrOf gets a pointer to the value in rv, for types with pointer-receiver
+// String methods. Non-addressable values are copied.
+func addrOf[T any](rv reflect.Value) *T {
+	if rv.CanAddr() {
+		return rv.Addr().Interface().(*T)
+	}
+
+	v := rv.Interface().(T)
+	return &v
+}
+
+// mutexLocked digs the state out of a [sync.Mutex], whose layout varies
+// between Go versions.
+func mutexLocked(rv reflect.Value) (locked, ok bool) {
+	const lockedBit = 1
+
+	for i := range rv.NumField() {
+		f := rv.Field(i)
+
+		switch {
+		case rv.Type().Field(i).Name == "state" && f.Kind() == reflect.Int32:
+			return f.Int()&lockedBit != 0, true
+		case f.Kind() == reflect.Struct:
+			if locked, ok := mutexLocked(f); ok {
+				return locked, true
+			}
+		}
+	}
+
+	return false, false
+}
@@ -0,0 +1,53 @@
+package check
+
+import (
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDumpBuiltins(t *testing.T) {
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	Equal(t, testDump(tm), "time.Time(2024-01-02T03:04:05Z)")
+	Equal(t, testDump(time.UTC), "&time.Location(UTC)")
+	Equal(t, testDump(big.NewInt(-12345)), "&big.Int(-12345)")
+	Equal(t, testDump(net.IPv4(192, 0, 2, 1)), "net.IP(192.0.2.1)")
+	Equal(t, testDump(net.IP(nil)), "net.IP(nil)")
+	Equal(t, testDump(netip.MustParseAddr("::1")), "netip.Addr(::1)")
+	Equal(t, testDump(netip.MustParsePrefix("10.0.0.0/8")), "netip.Prefix(10.0.0.0/8)")
+	Equal(t, testDump(regexp.MustCompile(`^a+$`)), "&regexp.Regexp(`^a+$`)")
+	Equal(t, testDump(reflect.TypeFor[map[string]any]()), "reflect.Type(map[string]any)")
+
+	u, err := url.Parse("https://example.com/a?b=c")
+	MustNil(t, err)
+	Equal(t, testDump(u), "&url.URL(https://example.com/a?b=c)")
+
+	var mtx sync.Mutex
+	Equal(t, testDump(&mtx), "&sync.Mutex(unlocked)")
+	mtx.Lock()
+	Equal(t, testDump(&mtx), "&sync.Mutex(locked)")
+	mtx.Unlock()
+
+	t.Run("Nested", func(t *testing.T) {
+		v := struct {
+			At time.Time
+		}{
+			At: tm,
+		}
+
+		Equal(
+			t,
+			testDump(v),
+			"struct { At time.Time }{\n"+
+				dumpIndent+"At: time.Time(2024-01-02T03:04:05Z),\n"+
+				"}",
+		)
+	})
+}
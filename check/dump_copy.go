@@ -0,0 +1,85 @@
+package check
+
+import "reflect"
+
+// canInterface gets a version of rv that [reflect.Value.Interface] can be
+// called on, eg. for calling methods on values in unexported fields. Values
+// that can be rebuilt with plain reflection are copied so that dumps come out
+// the same with or without unsafe; everything else falls back to
+// forceCanInterface.
+func canInterface(rv reflect.Value) (reflect.Value, bool) {
+	if rv.CanInterface() {
+		return rv, true
+	}
+
+	if cp, ok := copyValue(rv); ok {
+		return cp, true
+	}
+
+	return forceCanInterface(rv)
+}
+
+// copyValue copies primitives, and slices, arrays, and maps of them, into new,
+// addressable values that aren't tainted by coming from unexported fields.
+func copyValue(rv reflect.Value) (reflect.Value, bool) {
+	rt := rv.Type()
+	cp := reflect.New(rt).Elem()
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		cp.SetBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		cp.SetInt(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		cp.SetUint(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		cp.SetFloat(rv.Float())
+	case reflect.Complex64, reflect.Complex128:
+		cp.SetComplex(rv.Complex())
+	case reflect.String:
+		cp.SetString(rv.String())
+
+	case reflect.Slice:
+		if rv.IsNil() {
+			break
+		}
+
+		cp.Set(reflect.MakeSlice(rt, rv.Len(), rv.Len()))
+		fallthrough
+
+	case reflect.Array:
+		for i := range rv.Len() {
+			el, ok := copyValue(rv.Index(i))
+			if !ok {
+				return reflect.Value{}, false
+			}
+
+			cp.Index(i).Set(el)
+		}
+
+	case reflect.Map:
+		if rv.IsNil() {
+			break
+		}
+
+		cp.Set(reflect.MakeMapWithSize(rt, rv.Len()))
+		for iter := rv.MapRange(); iter.Next(); {
+			k, ok := copyValue(iter.Key())
+			if !ok {
+				return reflect.Value{}, false
+			}
+
+			v, ok := copyValue(iter.Value())
+			if !ok {
+				return reflect.Value{}, false
+			}
+
+			cp.SetMapIndex(k, v)
+		}
+
+	default:
+		return reflect.Value{}, false
+	}
+
+	return cp, true
+}
@@ -0,0 +1,170 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Dumpable is implemented by types that want to control how they're rendered
+// in failure messages, eg. to hide secrets or collapse large caches. The output
+// is wrapped in the type's name, like a conversion: `pkg.Type(...)`.
+type Dumpable interface {
+	CheckDump(w DumpWriter)
+}
+
+// A DumpWriter writes a custom representation of a value into a dump
+type DumpWriter struct {
+	d *dumper
+}
+
+// WriteString writes s as-is. Any newlines are indented to match the
+// surrounding dump.
+func (w DumpWriter) WriteString(s string) {
+	for {
+		line, rest, found := strings.Cut(s, "\n")
+		w.d.buf.WriteString(line)
+		if !found {
+			return
+		}
+
+		w.d.buf.WriteByte('\n')
+		w.d.writeIndent()
+		s = rest
+	}
+}
+
+// Dump writes v as it would normally be dumped
+func (w DumpWriter) Dump(v any) {
+	if v == nil {
+		w.d.buf.WriteString("nil")
+	} else {
+		w.d.fmtVal(reflect.ValueOf(v))
+	}
+}
+
+type dumpFunc func(w DumpWriter, rv reflect.Value)
+
+type customDumper struct {
+	name string // Overrides the type's name, if set
+	fn   dumpFunc
+}
+
+var dumpers struct {
+	mtx sync.RWMutex
+	fns map[reflect.Type]customDumper
+}
+
+// RegisterDumper sets the function used to render values of type T in failure
+// messages, taking precedence over [Dumpable]. It's meant for types you don't
+// own; the output is wrapped in the type's name, like a conversion:
+// `pkg.Type(...)`. Only values of exactly type T are matched, so register
+// pointer types separately if needed.
+func RegisterDumper[T any](fn func(T) string) {
+	registerDumper(
+		reflect.TypeFor[T](),
+		"",
+		func(w DumpWriter, rv reflect.Value) {
+			w.WriteString(fn(rv.Interface().(T)))
+		})
+}
+
+func registerDumper(rt reflect.Type, name string, fn dumpFunc) {
+	dumpers.mtx.Lock()
+	defer dumpers.mtx.Unlock()
+
+	if dumpers.fns == nil {
+		dumpers.fns = make(map[reflect.Type]customDumper)
+	}
+
+	dumpers.fns[rt] = customDumper{
+		name: name,
+		fn:   fn,
+	}
+}
+
+func getDumper(rt reflect.Type) customDumper {
+	dumpers.mtx.RLock()
+	defer dumpers.mtx.RUnlock()
+
+	return dumpers.fns[rt]
+}
+
+var dumpableType = reflect.TypeFor[Dumpable]()
+
+// fmtCustom formats rv with a registered dumper or its CheckDump method, if
+// either exists. It returns false if rv should be formatted normally.
+func (d *dumper) fmtCustom(rv reflect.Value) (ok bool) {
+	var (
+		rt       = rv.Type()
+		cd       = getDumper(rt)
+		fn       = cd.fn
+		needAddr = false
+	)
+
+	if fn == nil {
+		switch rv.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			// Like annotations, let these resolve to their concrete types
+			return false
+		}
+
+		switch {
+		case rt.Implements(dumpableType):
+		case rv.CanAddr() && reflect.PointerTo(rt).Implements(dumpableType):
+			needAddr = true
+		default:
+			return false
+		}
+
+		fn = func(w DumpWriter, rv reflect.Value) {
+			if needAddr {
+				rv = rv.Addr()
+			}
+
+			rv.Interface().(Dumpable).CheckDump(w)
+		}
+	}
+
+	rv, ok = canInterface(rv)
+	if !ok {
+		return false
+	}
+
+	var (
+		start       = d.buf.Len()
+		indentDepth = d.indentDepth
+		nPath       = len(d.path)
+		nPaths      = len(d.paths)
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			// Throw away any partial output and fall back to normal formatting
+			d.buf.Truncate(start)
+			d.indentDepth = indentDepth
+			d.path = d.path[:nPath]
+			if d.paths != nil {
+				d.paths = d.paths[:nPaths]
+			}
+
+			d.buf.WriteString("/* ")
+			fmt.Fprintf(&d.buf, "(PANIC=%q)", r)
+			d.buf.WriteString(" */")
+			ok = false
+		}
+	}()
+
+	if cd.name != "" {
+		d.buf.WriteString(cd.name)
+	} else {
+		d.writeType(rv)
+	}
+
+	d.buf.WriteByte('(')
+	fn(DumpWriter{d: d}, rv)
+	d.buf.WriteByte(')')
+
+	return true
+}
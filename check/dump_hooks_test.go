@@ -0,0 +1,95 @@
+package check
+
+import (
+	"strings"
+	"testing"
+)
+
+type testDumpable struct {
+	Secret string
+}
+
+func (testDumpable) CheckDump(w DumpWriter) {
+	w.WriteString("<redacted>")
+}
+
+type testDumpablePtr struct {
+	ID int
+}
+
+func (v *testDumpablePtr) CheckDump(w DumpWriter) {
+	w.WriteString("id=")
+	w.Dump(v.ID)
+	w.WriteString("\nnext line")
+}
+
+type testDumpablePanics struct{}
+
+func (testDumpablePanics) CheckDump(w DumpWriter) {
+	w.WriteString("partial")
+	panic("dump panic")
+}
+
+type testRegistered struct {
+	a, b int
+}
+
+func init() {
+	RegisterDumper(func(v testRegistered) string {
+		return strings.Repeat("*", v.a+v.b)
+	})
+}
+
+func TestDumpable(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		Equal(
+			t,
+			testDump(testDumpable{Secret: "hunter2"}),
+			"check.testDumpable(<redacted>)",
+		)
+		Equal(
+			t,
+			testDump(&testDumpable{}),
+			"&check.testDumpable(<redacted>)",
+		)
+	})
+
+	t.Run("PointerReceiver", func(t *testing.T) {
+		Equal(
+			t,
+			testDump(&testDumpablePtr{ID: 1}),
+			"&check.testDumpablePtr(id=int(1)\nnext line)",
+		)
+		Equal(
+			t,
+			testDump([]testDumpablePtr{{ID: 1}}),
+			"[]check.testDumpablePtr{\n"+
+				dumpIndent+"check.testDumpablePtr(id=int(1)\n"+
+				dumpIndent+"next line),\n"+
+				"}",
+		)
+	})
+
+	t.Run("Panics", func(t *testing.T) {
+		Equal(
+			t,
+			testDump(testDumpablePanics{}),
+			`/* (PANIC="dump panic") */check.testDumpablePanics{}`,
+		)
+	})
+}
+
+func TestRegisterDumper(t *testing.T) {
+	Equal(
+		t,
+		testDump(testRegistered{a: 1, b: 2}),
+		"check.testRegistered(***)",
+	)
+	Equal(
+		t,
+		testDump(map[string]testRegistered{"k": {a: 1}}),
+		"map[string]check.testRegistered{\n"+
+			dumpIndent+`"k": check.testRegistered(*),`+"\n"+
+			"}",
+	)
+}
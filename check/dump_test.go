@@ -3,8 +3,10 @@ package check
 import (
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 	"testing"
+	"unsafe"
 )
 
 func testDump(v any) string {
@@ -237,6 +239,45 @@ func TestDumpGoString(t *testing.T) {
 	Equal(t, testDump(`"quotes"`), "`\"quotes\"`")
 }
 
+func TestDumpMultilineString(t *testing.T) {
+	Equal(t, testDump("line\n"), `"line\n"`)
+	Equal(
+		t,
+		testDump("a\nb\n"),
+		`""+`+"\n"+
+			dumpIndent+`"a\n"+`+"\n"+
+			dumpIndent+`"b\n"`,
+	)
+	Equal(
+		t,
+		testDump("a\nb"),
+		`""+`+"\n"+
+			dumpIndent+`"a\n"+`+"\n"+
+			dumpIndent+`"b" /* no newline at end */`,
+	)
+
+	t.Run("Nested", func(t *testing.T) {
+		type namedString string
+
+		Equal(
+			t,
+			testDump(struct{ S namedString }{S: "a\nb\n"}),
+			"struct { S check.namedString }{\n"+
+				dumpIndent+`S: check.namedString(""+`+"\n"+
+				dumpIndent+dumpIndent+`"a\n"+`+"\n"+
+				dumpIndent+dumpIndent+`"b\n"),`+"\n"+
+				"}",
+		)
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		msg := equalMsg("a\nb\nc\n", "a\nX\nc")
+		Contains(t, msg, "- "+dumpIndent+`"b\n"+`)
+		Contains(t, msg, "+ "+dumpIndent+`"X\n"+`)
+		Contains(t, msg, "+ "+dumpIndent+`"c" /* no newline at end */`)
+	})
+}
+
 func TestFmtBase10(t *testing.T) {
 	fmtInt64 := func(v int64) string {
 		buf := make([]byte, 0, maxBase10Len)
@@ -270,3 +311,294 @@ func TestFmtBase10(t *testing.T) {
 	Equal(t, fmtInt64(math.MinInt64), "-9_223_372_036_854_775_808")
 	Equal(t, fmtUint64(math.MaxUint64), "18_446_744_073_709_551_615")
 }
+
+func withSettings(t *testing.T, fn func(cfg *Config)) {
+	prev, prevFull := Settings, fullDump
+	t.Cleanup(func() {
+		Settings, fullDump = prev, prevFull
+	})
+
+	fullDump = false
+	fn(&Settings)
+}
+
+func TestDumpLimits(t *testing.T) {
+	t.Run("Depth", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.MaxDepth = 1 })
+
+		type node struct {
+			Next *node
+		}
+
+		Equal(
+			t,
+			testDump(node{Next: &node{}}),
+			"check.node{\n"+
+				dumpIndent+"Next: &check.node{...},\n"+
+				"}",
+		)
+	})
+
+	t.Run("Elems", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.MaxElems = 2 })
+
+		Equal(
+			t,
+			testDump(make([]int, 100_000)),
+			"[]int{\n"+
+				dumpIndent+"int(0),\n"+
+				dumpIndent+"int(0),\n"+
+				dumpIndent+"... 99,998 more elements\n"+
+				"}",
+		)
+		Equal(
+			t,
+			testDump(map[int]int{1: 1, 2: 2, 3: 3}),
+			"map[int]int{\n"+
+				dumpIndent+"int(1): int(1),\n"+
+				dumpIndent+"int(2): int(2),\n"+
+				dumpIndent+"... 1 more entries\n"+
+				"}",
+		)
+	})
+
+	t.Run("Len", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.MaxLen = 4 })
+
+		Equal(t, testDump("abcdef"), `"abcd" /* ... 2 more bytes */`)
+		Equal(t, testDump("abc☃"), `"abc" /* ... 3 more bytes */`)
+		Equal(
+			t,
+			testDump([]byte{1, 2, 3, 4, 5, 6}),
+			"[]uint8{\n"+
+				dumpIndent+"0x01, 0x02, 0x03, 0x04,\n"+
+				dumpIndent+"... 2 more bytes\n"+
+				"}",
+		)
+	})
+
+	t.Run("FullDump", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.MaxLen = 1 })
+		fullDump = true
+
+		Equal(t, testDump("abc"), `"abc"`)
+	})
+
+	t.Run("EqualMsg", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.MaxElems = 1 })
+
+		// The limits would hide the difference, so they're dropped
+		msg := equalMsg([]int{1, 2}, []int{1, 3})
+		Equal(t, msg, ""+
+			"Expected values to be equal:\n"+
+			"      []int{\n"+
+			"          int(1),\n"+
+			"    -     int(2),\n"+
+			"    +     int(3),\n"+
+			"      }",
+		)
+	})
+}
+
+func testDumpFunc() {}
+
+func TestDumpDeterministic(t *testing.T) {
+	withSettings(t, func(cfg *Config) { cfg.Deterministic = true })
+
+	var (
+		c0 = make(chan int)
+		c1 = make(chan int)
+		up = unsafe.Pointer(new(int))
+	)
+
+	Equal(
+		t,
+		Dump([]any{c0, c1, c0, up, (chan int)(nil)}),
+		"[]any{\n"+
+			dumpIndent+"any((chan int)(#1)),\n"+
+			dumpIndent+"any((chan int)(#2)),\n"+
+			dumpIndent+"any((chan int)(#1)),\n"+
+			dumpIndent+"any((unsafe.Pointer)(#3)),\n"+
+			dumpIndent+"any((chan int)(nil)),\n"+
+			"}",
+	)
+	Equal(t, Dump(uintptr(0x10)), "(uintptr)(0x10)")
+
+	t.Run("EqualMsg", func(t *testing.T) {
+		type S struct{ C chan int }
+
+		Equal(t, equalMsg(S{c0}, S{c1}), ""+
+			"Expected values to be equal:\n"+
+			"      check.S{\n"+
+			"    -     C: (chan int)(#1),\n"+
+			"    +     C: (chan int)(#2),\n"+
+			"      }",
+		)
+
+		msg := equalMsg(map[int]chan int{1: c0}, map[int]chan int{1: c1})
+		Contains(t, msg, "- (chan int)(#1)")
+		Contains(t, msg, "+ (chan int)(#2)")
+
+		msg = equalMsg([]S{{c0}}, []S{{c1}})
+		Contains(t, msg, "-     C: (chan int)(#1),")
+		Contains(t, msg, "+     C: (chan int)(#2),")
+	})
+}
+
+func TestDumpFunc(t *testing.T) {
+	dumped := Dump(testDumpFunc)
+	Contains(t, dumped, "(func())(github.com/thatguystone/cog/check.testDumpFunc /* dump_test.go:")
+	Equal(t, Dump((func())(nil)), "(func())(nil)")
+}
+
+func TestDumpHexdump(t *testing.T) {
+	withSettings(t, func(cfg *Config) { cfg.HexdumpMin = 16 })
+
+	b := make([]byte, 70)
+	copy(b, "hello, world\x00\x01\x02\x03")
+	copy(b[64:], "tail")
+
+	Equal(
+		t,
+		testDump(b),
+		"[]uint8{\n"+
+			dumpIndent+"00000000: 6865 6c6c 6f2c 2077 6f72 6c64 0001 0203  hello, world....\n"+
+			dumpIndent+"00000010: 0000 0000 0000 0000 0000 0000 0000 0000  ................\n"+
+			dumpIndent+"*\n"+
+			dumpIndent+"00000040: 7461 696c 0000                           tail..\n"+
+			"}",
+	)
+
+	var arr [16]byte
+	Equal(
+		t,
+		testDump(arr),
+		"[16]uint8{\n"+
+			dumpIndent+"00000000: 0000 0000 0000 0000 0000 0000 0000 0000  ................\n"+
+			"}",
+	)
+
+	// Short slices are still shown as lists
+	Equal(
+		t,
+		testDump([]byte{1}),
+		"[]uint8{\n"+
+			dumpIndent+"0x01,\n"+
+			"}",
+	)
+}
+
+func TestDumpBytesAsText(t *testing.T) {
+	withSettings(t, func(cfg *Config) { cfg.BytesAsText = true })
+
+	Equal(t, testDump([]byte("text")), `[]uint8("text")`)
+	type raw []byte
+	Equal(t, testDump(raw(`{"a":1}`)), "check.raw(`{\"a\":1}`)")
+	Equal(
+		t,
+		testDump([]byte{0xff}),
+		"[]uint8{\n"+
+			dumpIndent+"0xff,\n"+
+			"}",
+	)
+
+	t.Run("Len", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.MaxLen = 4 })
+
+		Equal(t, testDump([]byte("abcdef")), `[]uint8("abcd" /* ... 2 more bytes */)`)
+		Equal(t, testDump([]byte("abc☃")), `[]uint8("abc" /* ... 3 more bytes */)`)
+
+		// Only the bytes that are shown need to be text
+		b := append([]byte("abcd"), 0xff)
+		Equal(t, testDump(b), `[]uint8("abcd" /* ... 1 more bytes */)`)
+	})
+}
+
+func TestDumpUnexported(t *testing.T) {
+	type fields struct {
+		Exported string
+		str      testStringer
+		strs     []testStringer
+		errs     map[string]testError
+	}
+
+	v := fields{
+		Exported: "e",
+		str:      "s",
+		strs:     []testStringer{"a"},
+		errs:     map[string]testError{"k": "err"},
+	}
+
+	t.Run("Annotations", func(t *testing.T) {
+		// Output must be identical with and without unsafe
+		Equal(
+			t,
+			testDump(v),
+			"check.fields{\n"+
+				dumpIndent+`Exported: "e",`+"\n"+
+				dumpIndent+`str: /* "s" */check.testStringer("s"),`+"\n"+
+				dumpIndent+"strs: []check.testStringer{\n"+
+				dumpIndent+dumpIndent+`/* "a" */check.testStringer("a"),`+"\n"+
+				dumpIndent+"},\n"+
+				dumpIndent+"errs: map[string]check.testError{\n"+
+				dumpIndent+dumpIndent+`"k": /* "err" */check.testError("err"),`+"\n"+
+				dumpIndent+"},\n"+
+				"}",
+		)
+	})
+
+	t.Run("Hide", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.HideUnexported = true })
+
+		Equal(
+			t,
+			testDump(v),
+			"check.fields{\n"+
+				dumpIndent+`Exported: "e",`+"\n"+
+				"}",
+		)
+		Equal(t, testDump(struct{ a int }{}), "struct { a int }{}")
+	})
+}
+
+func TestCopyValue(t *testing.T) {
+	v := struct {
+		i  int
+		ss []string
+		ns []int
+		a  [2]bool
+		m  map[string]float64
+		nm map[int]int
+		c  complex64
+		u  uint8
+		p  *int
+	}{
+		i:  1,
+		ss: []string{"a"},
+		a:  [2]bool{true, false},
+		m:  map[string]float64{"k": 1.5},
+		c:  1 + 2i,
+		u:  3,
+	}
+
+	expect := []any{
+		1,
+		[]string{"a"},
+		[]int(nil),
+		[2]bool{true, false},
+		map[string]float64{"k": 1.5},
+		map[int]int(nil),
+		complex64(1 + 2i),
+		uint8(3),
+	}
+
+	rv := reflect.ValueOf(v)
+	for i, e := range expect {
+		cp, ok := copyValue(rv.Field(i))
+		MustTrue(t, ok)
+		Equal(t, cp.Interface(), e)
+	}
+
+	_, ok := copyValue(rv.FieldByName("p"))
+	False(t, ok)
+}
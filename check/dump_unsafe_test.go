@@ -0,0 +1,13 @@
+//go:build !(appengine || purego)
+
+package check
+
+import "testing"
+
+func TestDumpableUnexported(t *testing.T) {
+	v := struct {
+		d testDumpable
+	}{}
+
+	Contains(t, testDump(v), "d: check.testDumpable(<redacted>)")
+}
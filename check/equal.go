@@ -0,0 +1,267 @@
+package check
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// RegisterEqual registers fn to compare values of type T, anywhere they're
+// found, instead of comparing them field by field. This is useful for types
+// you don't own, which can't be given an Equal method. Like Equal methods, fn
+// isn't used for values in unexported struct fields.
+func RegisterEqual[T any](fn func(a, b T) bool) {
+	equalFuncs.mtx.Lock()
+	defer equalFuncs.mtx.Unlock()
+
+	if equalFuncs.fns == nil {
+		equalFuncs.fns = make(map[reflect.Type]func(a, b reflect.Value) bool)
+	}
+
+	equalFuncs.fns[reflect.TypeFor[T]()] = func(a, b reflect.Value) bool {
+		return fn(a.Interface().(T), b.Interface().(T))
+	}
+
+	equalFuncs.cache.Clear()
+}
+
+var equalFuncs struct {
+	mtx sync.RWMutex
+	fns map[reflect.Type]func(a, b reflect.Value) bool
+
+	// Resolved funcs, including nil ones, by equalCacheKey, since looking up
+	// methods is slow and is done for every value compared
+	cache sync.Map
+}
+
+type equalCacheKey struct {
+	rt      reflect.Type
+	methods bool
+}
+
+func getEqualFunc(rt reflect.Type) func(a, b reflect.Value) bool {
+	key := equalCacheKey{rt, Settings.EqualMethods}
+	if fn, ok := equalFuncs.cache.Load(key); ok {
+		return fn.(func(a, b reflect.Value) bool)
+	}
+
+	fn := resolveEqualFunc(rt)
+	equalFuncs.cache.Store(key, fn)
+	return fn
+}
+
+func resolveEqualFunc(rt reflect.Type) func(a, b reflect.Value) bool {
+	equalFuncs.mtx.RLock()
+	fn := equalFuncs.fns[rt]
+	equalFuncs.mtx.RUnlock()
+
+	if fn != nil || !Settings.EqualMethods || rt.Kind() == reflect.Interface {
+		return fn
+	}
+
+	m, ok := rt.MethodByName("Equal")
+	if !ok {
+		return nil
+	}
+
+	// Method types include the receiver
+	mt := m.Type
+	if mt.NumIn() != 2 || mt.In(1) != rt || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return nil
+	}
+
+	return func(a, b reflect.Value) bool {
+		return a.Method(m.Index).Call([]reflect.Value{b})[0].Bool()
+	}
+}
+
+// deepEqual is like [reflect.DeepEqual], but values with a func registered
+// with [RegisterEqual] or, with [Config.EqualMethods], an `Equal(T) bool`
+// method, are compared with it.
+func deepEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	eq := equaler{
+		visited: map[equalVisit]bool{},
+	}
+
+	return eq.equal(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+type equaler struct {
+	visited map[equalVisit]bool
+}
+
+// equalVisit is a comparison in progress, to avoid following cycles forever.
+// Slices sharing a backing array only hold the same elements if their lengths
+// match too, so len is part of the key.
+type equalVisit struct {
+	a, b uintptr
+	len  int
+	typ  reflect.Type
+}
+
+func (eq equaler) equal(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+	}
+
+	// Values read from unexported fields can only be passed to fn with
+	// unsafe, which isn't always available, and whether a check passes can't
+	// depend on build tags, so they're always compared field by field.
+	if a.CanInterface() && b.CanInterface() {
+		if fn := getEqualFunc(a.Type()); fn != nil {
+			return fn(a, b)
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		visit := equalVisit{a.Pointer(), b.Pointer(), 0, a.Type()}
+		if a.Kind() == reflect.Slice {
+			// Lengths must match before the elements are compared
+			if a.Len() != b.Len() {
+				return false
+			}
+
+			visit.len = a.Len()
+		}
+
+		if eq.visited[visit] {
+			return true
+		}
+
+		eq.visited[visit] = true
+	}
+
+	switch a.Kind() {
+	case reflect.Array:
+		return eq.equalElems(a, b)
+
+	case reflect.Slice:
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+
+		if a.Type().Elem().Kind() == reflect.Uint8 && getEqualFunc(a.Type().Elem()) == nil {
+			return bytes.Equal(a.Bytes(), b.Bytes())
+		}
+
+		return eq.equalElems(a, b)
+
+	case reflect.Interface:
+		return eq.equal(a.Elem(), b.Elem())
+
+	case reflect.Pointer:
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+
+		return eq.equal(a.Elem(), b.Elem())
+
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if !eq.equal(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := b.MapIndex(iter.Key())
+			if !bv.IsValid() || !eq.equal(iter.Value(), bv) {
+				return false
+			}
+		}
+
+		return true
+
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+
+	default:
+		cmp := basicEqual(a.Kind())
+		if cmp == nil {
+			panic(fmt.Errorf("check: unsupported kind %s", a.Kind()))
+		}
+
+		return cmp(a, b)
+	}
+}
+
+func (eq equaler) equalElems(a, b reflect.Value) bool {
+	if elem := a.Type().Elem(); getEqualFunc(elem) == nil {
+		if cmp := basicEqual(elem.Kind()); cmp != nil {
+			for i := range a.Len() {
+				if !cmp(a.Index(i), b.Index(i)) {
+					return false
+				}
+			}
+
+			return true
+		}
+	}
+
+	for i := range a.Len() {
+		if !eq.equal(a.Index(i), b.Index(i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// basicEqual gets a func to compare values of a kind that can't contain
+// other values, or nil for other kinds
+func basicEqual(kind reflect.Kind) func(a, b reflect.Value) bool {
+	switch kind {
+	case reflect.Bool:
+		return func(a, b reflect.Value) bool { return a.Bool() == b.Bool() }
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) bool { return a.Int() == b.Int() }
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b reflect.Value) bool { return a.Uint() == b.Uint() }
+
+	case reflect.Float32, reflect.Float64:
+		return func(a, b reflect.Value) bool { return a.Float() == b.Float() }
+
+	case reflect.Complex64, reflect.Complex128:
+		return func(a, b reflect.Value) bool { return a.Complex() == b.Complex() }
+
+	case reflect.String:
+		return func(a, b reflect.Value) bool { return a.String() == b.String() }
+
+	default:
+		return nil
+	}
+}
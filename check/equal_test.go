@@ -0,0 +1,130 @@
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type equalTimes struct {
+	At   time.Time
+	Ats  []time.Time
+	ByID map[string]*time.Time
+}
+
+type equalFold string
+
+func TestDeepEqualMethods(t *testing.T) {
+	var (
+		now = time.Now()
+		utc = now.UTC().Round(0)
+	)
+
+	g := equalTimes{
+		At:   now,
+		Ats:  []time.Time{now},
+		ByID: map[string]*time.Time{"a": &now},
+	}
+	e := equalTimes{
+		At:   utc,
+		Ats:  []time.Time{utc},
+		ByID: map[string]*time.Time{"a": &utc},
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		testCheck(checkEqual(g, e))(t, true)
+		testCheck(checkEqual(g, equalTimes{At: utc.Add(1)}))(t, false)
+		testCheck(checkContains([]any{1, e}, g))(t, true)
+	})
+
+	t.Run("Unexported", func(t *testing.T) {
+		type W struct{ at time.Time }
+
+		// The same with and without unsafe
+		testCheck(checkEqual(W{now}, W{now}))(t, true)
+		testCheck(checkEqual(W{now}, W{utc}))(t, false)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.EqualMethods = false })
+		testCheck(checkEqual(g, e))(t, false)
+	})
+}
+
+func TestDeepEqualRegistered(t *testing.T) {
+	// Lookups are cached, so registering has to reset them
+	testCheck(checkEqual(equalFold("hello"), equalFold("HELLO")))(t, false)
+
+	RegisterEqual(func(a, b equalFold) bool {
+		return strings.EqualFold(string(a), string(b))
+	})
+
+	testCheck(checkEqual(
+		map[string][]equalFold{"a": {"Hello"}},
+		map[string][]equalFold{"a": {"HELLO"}},
+	))(t, true)
+	testCheck(checkEqual(equalFold("hello"), equalFold("world")))(t, false)
+	testCheck(checkSubset([]equalFold{"a"}, []equalFold{"A", "b"}))(t, true)
+}
+
+func TestDeepEqualCycles(t *testing.T) {
+	type node struct {
+		Next *node
+		Val  int
+	}
+
+	a := &node{Val: 1}
+	a.Next = a
+	b := &node{Val: 1}
+	b.Next = b
+
+	testCheck(checkEqual(a, b))(t, true)
+
+	b.Val = 2
+	testCheck(checkEqual(a, b))(t, false)
+
+	sa := []any{1, nil}
+	sa[1] = sa
+	sb := []any{1, nil}
+	sb[1] = sb
+	Equal(t, deepEqual(sa, sb), true)
+}
+
+func TestDeepEqualMatchesReflect(t *testing.T) {
+	type subslices struct {
+		A, B []int
+	}
+
+	var (
+		ch = make(chan int)
+		fn = func() {}
+		a  = []int{1, 2}
+		b  = []int{1, 3}
+	)
+
+	tests := []struct {
+		g, e  any
+		equal bool
+	}{
+		{nil, nil, true},
+		{nil, 1, false},
+		{1, int64(1), false},
+		{[]int(nil), []int{}, false},
+		{map[int]int(nil), map[int]int{}, false},
+		{map[int]int{1: 2}, map[int]int{1: 3}, false},
+		{map[int]int{1: 2}, map[int]int{2: 2}, false},
+		{[2]any{1, "a"}, [2]any{1, "a"}, true},
+		{ch, ch, true},
+		{ch, make(chan int), false},
+		{(func())(nil), (func())(nil), true},
+		{fn, fn, false},
+		{struct{ a, b float64 }{1, 2}, struct{ a, b float64 }{1, 2}, true},
+		{complex(1, 2), complex(1, 3), false},
+		{subslices{a[:1], a}, subslices{b[:1], b}, false},
+		{[][]int{a[:1], a[:2]}, [][]int{b[:1], b[:2]}, false},
+	}
+
+	for _, test := range tests {
+		Equalf(t, deepEqual(test.g, test.e), test.equal, "%s == %s", Dump(test.g), Dump(test.e))
+	}
+}
@@ -0,0 +1,97 @@
+package check
+
+import (
+	"go/ast"
+	"go/parser"
+	"strings"
+
+	"github.com/thatguystone/cog/callstack"
+)
+
+// ExprMsg prefixes msg with the source expression and dump of each value
+// passed to the check that called ExprMsg, skipping literals since their dumps
+// say nothing new. Values that dump to more than one line aren't repeated,
+// since msg usually shows them already. vals are the check's args after t, in
+// order.
+//
+// This is meant for wrappers generated by package checkgen; it must be called
+// directly from the check that failed.
+func ExprMsg(msg string, vals ...any) string {
+	args := callstack.CallArgs(1)
+	if len(args) < len(vals)+1 {
+		return msg
+	}
+
+	args = args[1:] // Skip t
+
+	var b strings.Builder
+	for i, val := range vals {
+		if _, ok := val.(noExpr); ok || isLiteral(args[i]) {
+			continue
+		}
+
+		b.WriteString(args[i])
+		b.WriteString(": ")
+
+		if d := dump(val, 0); !strings.Contains(d, "\n") {
+			b.WriteString(d)
+		} else {
+			b.WriteString("(see below)")
+		}
+
+		b.WriteString("\n")
+	}
+
+	if b.Len() == 0 {
+		return msg
+	}
+
+	b.WriteString(msg)
+	return b.String()
+}
+
+type noExpr struct{}
+
+// NoExpr stands in for a value that [ExprMsg] should leave out
+var NoExpr any = noExpr{}
+
+func isLiteral(src string) bool {
+	expr, err := parser.ParseExpr(src)
+	return err == nil && isLiteralExpr(expr)
+}
+
+func isLiteralExpr(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.BasicLit, *ast.FuncLit:
+		return true
+
+	case *ast.Ident:
+		switch expr.Name {
+		case "nil", "true", "false":
+			return true
+		}
+
+	case *ast.UnaryExpr:
+		return isLiteralExpr(expr.X)
+
+	case *ast.BinaryExpr:
+		return isLiteralExpr(expr.X) && isLiteralExpr(expr.Y)
+
+	case *ast.ParenExpr:
+		return isLiteralExpr(expr.X)
+
+	case *ast.KeyValueExpr:
+		return isLiteralExpr(expr.Value)
+
+	case *ast.CompositeLit:
+		for _, elt := range expr.Elts {
+			if !isLiteralExpr(elt) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
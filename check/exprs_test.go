@@ -0,0 +1,84 @@
+package check
+
+import (
+	"fmt"
+	"testing"
+)
+
+type exprsRecorder struct {
+	msg string
+}
+
+func (r *exprsRecorder) Helper() {}
+
+func (r *exprsRecorder) Error(args ...any) {
+	r.msg = fmt.Sprint(args...)
+}
+
+func exprsNoExpr(_ Error, _, b any) string {
+	return ExprMsg("msg", NoExpr, b)
+}
+
+func TestExprMsg(t *testing.T) {
+	var (
+		r    = new(exprsRecorder)
+		user = struct{ Age int }{Age: 29}
+	)
+
+	Equal(r, user.Age, 30)
+	Equal(t, r.msg, ""+
+		"\n"+
+		"user.Age: int(29)\n"+
+		"Expected: int(29)\n"+
+		"       == int(30)",
+	)
+
+	Truef(r, user.Age > 30, "too young")
+	Equal(t, r.msg, ""+
+		"too young\n"+
+		"user.Age > 30: false\n"+
+		"Expected true",
+	)
+
+	Equal(t, exprsNoExpr(r, user, user.Age), "user.Age: int(29)\nmsg")
+
+	Equal(r, []int{1}, []int{2, -3})
+	Equal(t, r.msg, ""+
+		"\n"+
+		"Expected values to be equal:\n"+
+		"      []int{\n"+
+		"    -     int(1),\n"+
+		"    +     int(2),\n"+
+		"    +     int(-3),\n"+
+		"      }",
+	)
+
+	items := []int{1}
+	Equal(r, items, []int{2})
+	Equal(t, r.msg, ""+
+		"\n"+
+		"items: (see below)\n"+
+		"Expected values to be equal:\n"+
+		"      []int{\n"+
+		"    -     int(1),\n"+
+		"    +     int(2),\n"+
+		"      }",
+	)
+}
+
+func TestIsLiteral(t *testing.T) {
+	True(t, isLiteral(`1`))
+	True(t, isLiteral(`-1.5`))
+	True(t, isLiteral(`"str"`))
+	True(t, isLiteral(`nil`))
+	True(t, isLiteral(`"a" + "b"`))
+	True(t, isLiteral(`func() {}`))
+	True(t, isLiteral(`[]int{1, 2}`))
+	True(t, isLiteral(`map[string]int{"a": 1}`))
+	False(t, isLiteral(`a`))
+	False(t, isLiteral(`-a`))
+	False(t, isLiteral(`[]int{a}`))
+	False(t, isLiteral(`f()`))
+	False(t, isLiteral(`"a" + b`))
+	False(t, isLiteral(`{`))
+}
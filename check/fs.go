@@ -0,0 +1,237 @@
+package check
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"testing/fstest"
+	"unicode/utf8"
+
+	"github.com/thatguystone/cog/textwrap"
+	"golang.org/x/tools/txtar"
+)
+
+func checkFileExists(path string) (string, bool) {
+	info, err := os.Stat(path)
+	switch {
+	case err != nil:
+		return "Expected file to exist:\n" + dump(err, 1), false
+	case info.IsDir():
+		return fmt.Sprintf("Expected %q to be a file, but it's a directory", path), false
+	}
+
+	return "", true
+}
+
+func checkDirExists(path string) (string, bool) {
+	info, err := os.Stat(path)
+	switch {
+	case err != nil:
+		return "Expected directory to exist:\n" + dump(err, 1), false
+	case !info.IsDir():
+		return fmt.Sprintf("Expected %q to be a directory, but it's %s", path, fileKind(info.Mode())), false
+	}
+
+	return "", true
+}
+
+// fileKind describes the type of file mode is for, eg. "a file"
+func fileKind(mode fs.FileMode) string {
+	switch mode.Type() {
+	case 0:
+		return "a file"
+	case fs.ModeDir:
+		return "a directory"
+	case fs.ModeSymlink:
+		return "a symlink"
+	case fs.ModeNamedPipe:
+		return "a named pipe"
+	case fs.ModeSocket:
+		return "a socket"
+	case fs.ModeDevice:
+		return "a device"
+	case fs.ModeDevice | fs.ModeCharDevice:
+		return "a character device"
+	default:
+		return "an irregular file"
+	}
+}
+
+func checkFileContent(path, want string) (string, bool) {
+	got, err := os.ReadFile(path)
+	if err != nil {
+		return "Failed to read file:\n" + dump(err, 1), false
+	}
+
+	if string(got) == want {
+		return "", true
+	}
+
+	return fmt.Sprintf("File %q has unexpected contents:\n", path) +
+		textwrap.Indent(equalMsg(string(got), want), dumpIndent), false
+}
+
+// Txtar creates an [fs.FS] from a txtar archive, for use with [TreeEqual]. The
+// archive's comment is ignored.
+func Txtar(archive string) fs.FS {
+	fsys := fstest.MapFS{}
+	for _, f := range txtar.Parse([]byte(archive)).Files {
+		fsys[f.Name] = &fstest.MapFile{
+			Data: f.Data,
+			Mode: 0644,
+		}
+	}
+
+	return fsys
+}
+
+// treeFS gets an [fs.FS] for a tree given to TreeEqual
+func treeFS(tree any) (fs.FS, error) {
+	switch tree := tree.(type) {
+	case string:
+		return os.DirFS(tree), nil
+	case fs.FS:
+		return tree, nil
+	default:
+		return nil, fmt.Errorf("unsupported tree type %T; must be a directory path or fs.FS", tree)
+	}
+}
+
+// readTree reads every regular file in a tree
+func readTree(tree any) (map[string][]byte, error) {
+	fsys, err := treeFS(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+
+		files[path], err = fs.ReadFile(fsys, path)
+		return err
+	})
+
+	return files, err
+}
+
+func checkTreeEqual(g, e any) (string, bool) {
+	gFiles, err := readTree(g)
+	if err != nil {
+		return "Failed to read got tree:\n" + dump(err, 1), false
+	}
+
+	eFiles, err := readTree(e)
+	if err != nil {
+		return "Failed to read want tree:\n" + dump(err, 1), false
+	}
+
+	var onlyG, onlyE, changed []string
+	for path, data := range gFiles {
+		eData, ok := eFiles[path]
+		switch {
+		case !ok:
+			onlyG = append(onlyG, path)
+		case !bytes.Equal(data, eData):
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range eFiles {
+		if _, ok := gFiles[path]; !ok {
+			onlyE = append(onlyE, path)
+		}
+	}
+
+	if len(onlyG) == 0 && len(onlyE) == 0 && len(changed) == 0 {
+		return "", true
+	}
+
+	slices.Sort(onlyG)
+	slices.Sort(onlyE)
+	slices.Sort(changed)
+
+	var (
+		b       strings.Builder
+		prefix  = dumpIndent + dumpIndent
+		prefix2 = prefix + dumpIndent
+	)
+
+	b.WriteString("Expected trees to be equal:\n")
+
+	writePaths := func(title string, paths []string) {
+		if len(paths) == 0 {
+			return
+		}
+
+		b.WriteString(dumpIndent + title + "\n")
+		for _, path := range paths {
+			b.WriteString(prefix + path + "\n")
+		}
+	}
+
+	writePaths("Only in got:", onlyG)
+	writePaths("Only in want:", onlyE)
+
+	if len(changed) > 0 {
+		b.WriteString(dumpIndent + "Different contents:\n")
+	}
+
+	for _, path := range changed {
+		b.WriteString(prefix + path + ":\n")
+
+		gData, eData := gFiles[path], eFiles[path]
+		if isTextFile(gData) && isTextFile(eData) {
+			writeDiff(
+				&b,
+				diffDumps(textLines(gData), textLines(eData)),
+				Settings.DiffContext,
+				prefix2,
+			)
+		} else {
+			fmt.Fprintf(&b, "%sgot:  %s\n", prefix2, fileSummary(gData))
+			fmt.Fprintf(&b, "%swant: %s\n", prefix2, fileSummary(eData))
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n"), false
+}
+
+func isTextFile(data []byte) bool {
+	return utf8.Valid(data) && bytes.IndexByte(data, 0) < 0
+}
+
+// textLines splits a text file into lines for diffing. Like git, a missing
+// newline at the end gets its own marker line, so it shows up in diffs.
+func textLines(data []byte) dumpedLines {
+	text, hasNewline := strings.CutSuffix(string(data), "\n")
+
+	var lines []string
+	if text != "" || hasNewline {
+		lines = strings.Split(text, "\n")
+	}
+
+	if !hasNewline && text != "" {
+		lines = append(lines, `\ No newline at end of file`)
+	}
+
+	return rawLines(lines)
+}
+
+// rawLines wraps lines of text for diffing
+func rawLines(lines []string) dumpedLines {
+	return dumpedLines{
+		lines: lines,
+		paths: make([][]string, len(lines)),
+	}
+}
+
+func fileSummary(data []byte) string {
+	return fmt.Sprintf("%s bytes, sha256 %x", fmtCount(len(data)), sha256.Sum256(data))
+}
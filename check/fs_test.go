@@ -0,0 +1,124 @@
+package check
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckFileExists(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+
+	err := os.WriteFile(path, nil, 0640)
+	MustNil(t, err)
+
+	testCheck(checkFileExists(path))(t, true)
+	testCheck(checkFileExists(dir))(t, false)
+	testCheck(checkFileExists(path+"nope"))(t, false)
+}
+
+func TestCheckDirExists(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+
+	err := os.WriteFile(path, nil, 0640)
+	MustNil(t, err)
+
+	testCheck(checkDirExists(dir))(t, true)
+	testCheck(checkDirExists(path))(t, false)
+	testCheck(checkDirExists(path+"nope"))(t, false)
+
+	msg, _ := checkDirExists(path)
+	Equal(t, msg, fmt.Sprintf("Expected %q to be a directory, but it's a file", path))
+}
+
+func TestFileKind(t *testing.T) {
+	Equal(t, fileKind(0640), "a file")
+	Equal(t, fileKind(fs.ModeDir|0750), "a directory")
+	Equal(t, fileKind(fs.ModeSymlink), "a symlink")
+	Equal(t, fileKind(fs.ModeNamedPipe), "a named pipe")
+	Equal(t, fileKind(fs.ModeDevice|fs.ModeCharDevice), "a character device")
+	Equal(t, fileKind(fs.ModeIrregular), "an irregular file")
+}
+
+func TestTextLines(t *testing.T) {
+	Equal(t, textLines(nil).lines, []string(nil))
+	Equal(t, textLines([]byte("\n")).lines, []string{""})
+	Equal(t, textLines([]byte("a\nb\n")).lines, []string{"a", "b"})
+	Equal(t, textLines([]byte("a")).lines, []string{"a", `\ No newline at end of file`})
+}
+
+func TestCheckFileContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+
+	err := os.WriteFile(path, []byte("a\nb\n"), 0640)
+	MustNil(t, err)
+
+	testCheck(checkFileContent(path, "a\nb\n"))(t, true)
+	testCheck(checkFileContent(path, "a\nc\n"))(t, false)
+	testCheck(checkFileContent(path+"nope", ""))(t, false)
+
+	msg, _ := checkFileContent(path, "a\nc\n")
+	Contains(t, msg, `-     "b\n"`)
+	Contains(t, msg, `+     "c\n"`)
+}
+
+func TestCheckTreeEqual(t *testing.T) {
+	dir := t.TempDir()
+
+	for path, data := range map[string]string{
+		"same.txt":    "same\n",
+		"sub/got.txt": "got\n",
+		"text.txt":    "a\nb\nc\n",
+		"bin":         "\x00\x01",
+	} {
+		path = filepath.Join(dir, path)
+
+		err := os.MkdirAll(filepath.Dir(path), 0750)
+		MustNil(t, err)
+
+		err = os.WriteFile(path, []byte(data), 0640)
+		MustNil(t, err)
+	}
+
+	testCheck(checkTreeEqual(dir, dir))(t, true)
+	testCheck(checkTreeEqual(dir, os.DirFS(dir)))(t, true)
+
+	want := Txtar(`
+-- same.txt --
+same
+-- want.txt --
+want
+-- text.txt --
+a
+B
+c
+-- bin --
+` + "\x00\x02\n")
+
+	msg, ok := checkTreeEqual(dir, want)
+	False(t, ok)
+	Equal(t, msg, ""+
+		"Expected trees to be equal:\n"+
+		"    Only in got:\n"+
+		"        sub/got.txt\n"+
+		"    Only in want:\n"+
+		"        want.txt\n"+
+		"    Different contents:\n"+
+		"        bin:\n"+
+		"            got:  2 bytes, sha256 b413f47d13ee2fe6c845b2ee141af81de858df4ec549a58b7970bb96645bc8d2\n"+
+		"            want: 3 bytes, sha256 d3a8929387899076b400f88663953c28e3df602e4eda5e89aded70dd0a98536b\n"+
+		"        text.txt:\n"+
+		"              a\n"+
+		"            - b\n"+
+		"            + B\n"+
+		"              c",
+	)
+
+	testCheck(checkTreeEqual(1, dir))(t, false)
+	testCheck(checkTreeEqual(dir, 1))(t, false)
+	testCheck(checkTreeEqual(filepath.Join(dir, "nope"), dir))(t, false)
+}
@@ -0,0 +1,218 @@
+package check
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	allocRuns     = 100 // Runs averaged by [testing.AllocsPerRun]
+	allocSiteRuns = 10  // Runs profiled to find allocation sites
+	maxAllocSites = 10  // Max number of allocation sites shown
+
+	perfWarmups = 3  // Untimed runs before sampling
+	perfSamples = 10 // Timed runs
+)
+
+func checkAllocsAtMost(n float64, fn func()) (string, bool) {
+	allocs := testing.AllocsPerRun(allocRuns, fn)
+	if allocs <= n {
+		return "", true
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Expected at most %g allocs per run, got %g", n, allocs)
+
+	if Settings.AllocSites {
+		sites := allocSites(fn)
+		if len(sites) > 0 {
+			b.WriteString("\nAllocations per run, by site:")
+		}
+
+		for i, site := range sites {
+			if i == maxAllocSites {
+				fmt.Fprintf(&b, "\n%s... %d more", dumpIndent, len(sites)-i)
+				break
+			}
+
+			fmt.Fprintf(
+				&b,
+				"\n%s%g: %s\n%s%s%s:%d",
+				dumpIndent, site.perRun, site.fn,
+				dumpIndent, dumpIndent, site.file, site.line,
+			)
+		}
+	}
+
+	return b.String(), false
+}
+
+type allocSite struct {
+	fn     string
+	file   string
+	line   int
+	perRun float64
+}
+
+// allocSites runs fn with every allocation profiled, attributing each to the
+// first non-runtime function in its stack
+func allocSites(fn func()) []allocSite {
+	prevRate := runtime.MemProfileRate
+	runtime.MemProfileRate = 1
+	defer func() { runtime.MemProfileRate = prevRate }()
+
+	// The profile is only updated once a GC cycle completes
+	runtime.GC()
+	before := memProfile()
+
+	profileAllocs(fn)
+
+	runtime.GC()
+	after := memProfile()
+
+	type siteKey struct {
+		fn, file string
+		line     int
+	}
+
+	counts := map[siteKey]int64{}
+	for stk, allocs := range after {
+		allocs -= before[stk]
+		if allocs <= 0 {
+			continue
+		}
+
+		frames := runtime.CallersFrames(stk.stack())
+
+		var (
+			site  runtime.Frame
+			found bool
+		)
+
+		for {
+			frame, more := frames.Next()
+			if frame.Function == profileAllocsName {
+				found = true
+				break
+			}
+
+			if site.PC == 0 && !strings.HasPrefix(frame.Function, "runtime.") {
+				site = frame
+			}
+
+			if !more {
+				break
+			}
+		}
+
+		// Allocations made by other goroutines, or with stacks too deep to
+		// be traced back to fn, can't be attributed
+		if found && site.PC != 0 {
+			counts[siteKey{site.Function, site.File, site.Line}] += allocs
+		}
+	}
+
+	sites := make([]allocSite, 0, len(counts))
+	for key, allocs := range counts {
+		sites = append(sites, allocSite{
+			fn:     key.fn,
+			file:   key.file,
+			line:   key.line,
+			perRun: float64(allocs) / allocSiteRuns,
+		})
+	}
+
+	slices.SortFunc(sites, func(a, b allocSite) int {
+		return cmp.Or(
+			cmp.Compare(b.perRun, a.perRun),
+			cmp.Compare(a.file, b.file),
+			cmp.Compare(a.line, b.line),
+		)
+	})
+
+	return sites
+}
+
+var profileAllocsName = runtime.FuncForPC(reflect.ValueOf(profileAllocs).Pointer()).Name()
+
+// profileAllocs marks allocations made by fn in the profile's stacks
+//
+//go:noinline
+func profileAllocs(fn func()) {
+	for range allocSiteRuns {
+		fn()
+	}
+}
+
+type memStack [32]uintptr
+
+func (stk memStack) stack() []uintptr {
+	for i, pc := range stk {
+		if pc == 0 {
+			return stk[:i]
+		}
+	}
+
+	return stk[:]
+}
+
+// memProfile gets the number of objects allocated by each stack
+func memProfile() map[memStack]int64 {
+	var recs []runtime.MemProfileRecord
+	n, _ := runtime.MemProfile(nil, true)
+	for {
+		// Leave room for allocations made in the meantime
+		recs = make([]runtime.MemProfileRecord, n+50)
+
+		var ok bool
+		n, ok = runtime.MemProfile(recs, true)
+		if ok {
+			recs = recs[:n]
+			break
+		}
+	}
+
+	allocs := make(map[memStack]int64, len(recs))
+	for _, rec := range recs {
+		allocs[rec.Stack0] += rec.AllocObjects
+	}
+
+	return allocs
+}
+
+func checkFasterThan(budget time.Duration, fn func()) (string, bool) {
+	for range perfWarmups {
+		fn()
+	}
+
+	samples := make([]time.Duration, perfSamples)
+	for i := range samples {
+		start := time.Now()
+		fn()
+		samples[i] = time.Since(start)
+	}
+
+	slices.Sort(samples)
+
+	median := samples[len(samples)/2]
+	if median <= budget {
+		return "", true
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(
+		&b,
+		"Expected to run in at most %s, took %s (median of %d runs)",
+		budget, median, len(samples),
+	)
+	fmt.Fprintf(&b, "\n%smin: %s", dumpIndent, samples[0])
+	fmt.Fprintf(&b, "\n%smax: %s", dumpIndent, samples[len(samples)-1])
+
+	return b.String(), false
+}
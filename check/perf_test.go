@@ -0,0 +1,41 @@
+package check
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/thatguystone/cog/callstack"
+)
+
+var perfSink any
+
+func TestCheckAllocsAtMost(t *testing.T) {
+	testCheck(checkAllocsAtMost(0, func() {}))(t, true)
+	testCheck(checkAllocsAtMost(1, func() { perfSink = new([64]byte) }))(t, true)
+
+	t.Run("Sites", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.AllocSites = true })
+
+		line := callstack.Self().Frame().Line() + 1
+		msg, ok := checkAllocsAtMost(0, func() { perfSink = new([64]byte) })
+		False(t, ok)
+		Contains(t, msg, "Expected at most 0 allocs per run, got 1\n")
+		Contains(t, msg, "Allocations per run, by site:\n    1: ")
+		Contains(t, msg, fmt.Sprintf("perf_test.go:%d", line))
+	})
+
+	t.Run("NoSites", func(t *testing.T) {
+		msg, _ := checkAllocsAtMost(0, func() { perfSink = new([64]byte) })
+		Equal(t, msg, "Expected at most 0 allocs per run, got 1")
+	})
+}
+
+func TestCheckFasterThan(t *testing.T) {
+	testCheck(checkFasterThan(time.Second, func() {}))(t, true)
+
+	msg, ok := checkFasterThan(time.Microsecond, func() { time.Sleep(time.Millisecond) })
+	False(t, ok)
+	Contains(t, msg, "Expected to run in at most 1µs, took ")
+	Contains(t, msg, "(median of 10 runs)\n    min: ")
+}
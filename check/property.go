@@ -0,0 +1,479 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PropertyFunc is a property checked by [Property]. It fails by returning
+// false or an error, or by panicking.
+type PropertyFunc interface {
+	func(r *Rand) bool | func(r *Rand) error
+}
+
+// Property checks that fn holds for [Config.PropertyRuns] randomly-generated
+// inputs, drawn from r. When it fails, the input is shrunk to a minimal
+// counterexample, which is reported along with the seed that reproduces it.
+// Set the environment variable CHECK_PROPERTY_SEED to replay a seed.
+//
+// Since fn runs many times, it must not fail the test itself, eg. with
+// [MustEqual]; report failures by returning instead.
+func Property[F PropertyFunc](t Error, fn F) bool {
+	var propFn func(r *Rand) error
+	switch fn := any(fn).(type) {
+	case func(r *Rand) bool:
+		propFn = func(r *Rand) error {
+			if !fn(r) {
+				return errPropertyFalse
+			}
+
+			return nil
+		}
+
+	case func(r *Rand) error:
+		propFn = fn
+	}
+
+	if msg, ok := checkProperty(propertySeed(), Settings.PropertyRuns, propFn); !ok {
+		t.Helper()
+		msg = Report(t, "Property", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+var errPropertyFalse = errors.New("returned false")
+
+const (
+	// Max number of choices a single run may draw; after this, every choice
+	// is 0
+	maxChoices = 8192
+
+	// Max number of times a property is rerun while shrinking
+	maxShrinkRuns = 2000
+)
+
+func propertySeed() uint64 {
+	seed, err := strconv.ParseUint(os.Getenv("CHECK_PROPERTY_SEED"), 10, 64)
+	if err != nil {
+		return rand.Uint64()
+	}
+
+	return seed
+}
+
+func checkProperty(seed uint64, runs int, fn func(r *Rand) error) (string, bool) {
+	for run := range runs {
+		r := &Rand{
+			src: rand.New(rand.NewPCG(seed, uint64(run))),
+		}
+
+		err := r.call(fn)
+		if err == nil {
+			continue
+		}
+
+		s := shrinker{
+			fn:   fn,
+			best: r,
+			err:  err,
+		}
+
+		s.shrink()
+
+		var b strings.Builder
+		fmt.Fprintf(&b,
+			"Property failed on run %d of %d with seed %d "+
+				"(set CHECK_PROPERTY_SEED=%d to reproduce)\n",
+			run+1,
+			runs,
+			seed,
+			seed,
+		)
+
+		fmt.Fprintf(&b, "Minimal counterexample, after %d shrinks:\n", s.steps)
+		for _, drawn := range s.best.drawn {
+			fmt.Fprintf(&b, "%s%s: %s\n", dumpIndent, drawn.call, strings.TrimPrefix(dump(drawn.val, 1), dumpIndent))
+		}
+
+		fmt.Fprintf(&b, "Failure: %v", s.err)
+		return b.String(), false
+	}
+
+	return "", true
+}
+
+// A shrinker searches for the simplest choice sequence that still fails. Since
+// every value is built from choices, with 0 being the simplest choice, a
+// shorter or smaller sequence gives simpler values.
+type shrinker struct {
+	fn    func(r *Rand) error
+	best  *Rand
+	err   error
+	steps int
+	runs  int
+}
+
+// Chunk sizes to try deleting and zeroing. An element of a collection is a
+// choice to continue plus the choices for the element, eg. 4 for an int.
+var shrinkSizes = [...]int{8, 4, 3, 2, 1}
+
+func (s *shrinker) shrink() {
+	for improved := true; improved && s.runs < maxShrinkRuns; {
+		improved = false
+
+		// Delete chunks of choices, eg. elements of a collection
+		for _, size := range shrinkSizes {
+			for i := len(s.choices()) - size; i >= 0; i-- {
+				if i+size > len(s.choices()) {
+					continue
+				}
+
+				cand := slices.Delete(slices.Clone(s.choices()), i, i+size)
+				improved = s.try(cand) || improved
+			}
+		}
+
+		// Zero out chunks of choices
+		for _, size := range shrinkSizes {
+			for i := len(s.choices()) - size; i >= 0; i-- {
+				if i+size > len(s.choices()) {
+					continue
+				}
+
+				cand := slices.Clone(s.choices())
+				clear(cand[i : i+size])
+				improved = s.try(cand) || improved
+			}
+		}
+
+		// Minimize each choice
+		for i := 0; i < len(s.choices()); i++ {
+			lo, hi := uint64(0), s.choices()[i]
+			for lo < hi && i < len(s.choices()) {
+				mid := lo + (hi-lo)/2
+
+				cand := slices.Clone(s.choices())
+				cand[i] = mid
+				if s.try(cand) {
+					improved = true
+					hi = mid
+				} else {
+					lo = mid + 1
+				}
+			}
+		}
+	}
+}
+
+func (s *shrinker) choices() []uint64 {
+	return s.best.choices
+}
+
+// try reruns the property with the given choices, keeping them if they're
+// simpler and the property still fails
+func (s *shrinker) try(choices []uint64) bool {
+	if s.runs >= maxShrinkRuns || !shortlexLess(choices, s.choices()) {
+		return false
+	}
+
+	s.runs++
+
+	r := &Rand{
+		choices: choices,
+	}
+
+	err := r.call(s.fn)
+	if err == nil {
+		return false
+	}
+
+	r.choices = r.choices[:min(r.pos, len(r.choices))]
+	s.best = r
+	s.err = err
+	s.steps++
+
+	return true
+}
+
+func shortlexLess(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+
+	return slices.Compare(a, b) < 0
+}
+
+// Rand generates values for [Property]. Every value is built from a sequence
+// of choices, which is what makes shrinking possible.
+type Rand struct {
+	src     *rand.Rand // nil when replaying choices
+	choices []uint64
+	pos     int
+	depth   int
+	drawn   []drawnValue
+}
+
+type drawnValue struct {
+	call string
+	val  any
+}
+
+func (r *Rand) call(fn func(r *Rand) error) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+
+	return fn(r)
+}
+
+// choice makes the next choice in [0, n), or of any value if n is 0: random
+// when generating, from the recorded sequence when replaying, and 0 once the
+// sequence runs out. Choices are recorded already bounded, so that shrinking
+// a choice always shrinks the value built from it.
+func (r *Rand) choice(n uint64) uint64 {
+	defer func() { r.pos++ }()
+
+	if r.src == nil {
+		if r.pos >= len(r.choices) {
+			return 0
+		}
+
+		c := r.choices[r.pos]
+		if n > 0 {
+			c %= n
+		}
+
+		return c
+	}
+
+	if len(r.choices) >= maxChoices {
+		return 0
+	}
+
+	c := r.src.Uint64()
+	if n > 0 {
+		c %= n
+	}
+
+	r.choices = append(r.choices, c)
+	return c
+}
+
+// more decides if a collection gets another element. It's true 3/4 of the
+// time, so collections average 3 elements, and shrinking the choice to 0 ends
+// the collection.
+func (r *Rand) more(n int) bool {
+	const maxLen = 64
+	return n < maxLen && r.choice(4) != 0
+}
+
+// enter and leave wrap every exported method, so that only values drawn
+// directly by the property are shown in counterexamples
+func (r *Rand) enter() {
+	r.depth++
+}
+
+func (r *Rand) leave(call string, val any) {
+	r.depth--
+	if r.depth == 0 {
+		r.drawn = append(r.drawn, drawnValue{call, val})
+	}
+}
+
+// Bool draws a bool, shrinking towards false
+func (r *Rand) Bool() bool {
+	r.enter()
+	v := r.choice(2) == 1
+	r.leave("Bool()", v)
+	return v
+}
+
+// Int draws an int of any size, favoring small values and shrinking towards 0
+func (r *Rand) Int() int {
+	r.enter()
+	v := r.int()
+	r.leave("Int()", v)
+	return v
+}
+
+func (r *Rand) int() int {
+	var (
+		bits = [...]uint{4, 8, 16, 63}[r.choice(4)]
+		neg  = r.choice(2) == 1
+		v    = int(r.choice(1 << bits))
+	)
+
+	// Offset negatives by 1 so that every int, including MinInt, is reachable
+	if neg {
+		v = -v - 1
+	}
+
+	return v
+}
+
+// Intn draws an int in [0, n), shrinking towards 0. It panics if n <= 0.
+func (r *Rand) Intn(n int) int {
+	if n <= 0 {
+		panic(fmt.Errorf("check: invalid argument to Intn: %d", n))
+	}
+
+	r.enter()
+	v := int(r.choice(uint64(n)))
+	r.leave(fmt.Sprintf("Intn(%d)", n), v)
+	return v
+}
+
+// IntRange draws an int in [lo, hi], shrinking towards lo. It panics if
+// lo > hi.
+func (r *Rand) IntRange(lo, hi int) int {
+	if lo > hi {
+		panic(fmt.Errorf("check: invalid range for IntRange: [%d, %d]", lo, hi))
+	}
+
+	// For the full range of int, the bound overflows to 0, meaning unbounded
+	r.enter()
+	v := lo + int(r.choice(uint64(hi-lo)+1))
+	r.leave(fmt.Sprintf("IntRange(%d, %d)", lo, hi), v)
+	return v
+}
+
+// Float64 draws a float64 in [0, 1), shrinking towards 0
+func (r *Rand) Float64() float64 {
+	r.enter()
+	v := r.float64()
+	r.leave("Float64()", v)
+	return v
+}
+
+func (r *Rand) float64() float64 {
+	return float64(r.choice(1<<53)) / (1 << 53)
+}
+
+// Printable ASCII, simplest first, followed by a few troublemakers
+var randAlphabet = []rune("" +
+	"abcdefghijklmnopqrstuvwxyz" +
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
+	"0123456789" +
+	" !\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~" +
+	"\t\n\x00é世🙂\u200b\ufffd",
+)
+
+// Str draws a string, shrinking towards "". It's not called String since that
+// would make Rand a [fmt.Stringer] that draws every time it's printed.
+func (r *Rand) Str() string {
+	r.enter()
+	v := r.string()
+	r.leave("Str()", v)
+	return v
+}
+
+func (r *Rand) string() string {
+	var b strings.Builder
+	for n := 0; r.more(n); n++ {
+		b.WriteRune(randAlphabet[r.choice(uint64(len(randAlphabet)))])
+	}
+
+	return b.String()
+}
+
+// Arbitrary draws an arbitrary value of type T. Structs have their exported
+// fields filled in; chans, funcs, and interfaces are left as nil.
+func Arbitrary[T any](r *Rand) T {
+	r.enter()
+
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	r.fill(rv, 0)
+
+	r.leave("Arbitrary["+typeName(rv.Type())+"]()", v)
+	return v
+}
+
+// fill fills rv with an arbitrary value. Past maxDepth, recursive types get
+// their zero value, so that generation always ends.
+func (r *Rand) fill(rv reflect.Value, depth int) {
+	const maxDepth = 8
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		rv.SetBool(r.choice(2) == 1)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		rv.SetInt(int64(r.int()))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		rv.SetUint(uint64(r.int()))
+
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(float64(r.int()) + r.float64())
+
+	case reflect.Complex64, reflect.Complex128:
+		rv.SetComplex(complex(
+			float64(r.int())+r.float64(),
+			float64(r.int())+r.float64(),
+		))
+
+	case reflect.String:
+		rv.SetString(r.string())
+
+	case reflect.Array:
+		for i := range rv.Len() {
+			r.fill(rv.Index(i), depth+1)
+		}
+
+	case reflect.Slice:
+		if depth >= maxDepth {
+			return
+		}
+
+		rv.Set(reflect.MakeSlice(rv.Type(), 0, 0))
+		for n := 0; r.more(n); n++ {
+			el := reflect.New(rv.Type().Elem()).Elem()
+			r.fill(el, depth+1)
+			rv.Set(reflect.Append(rv, el))
+		}
+
+	case reflect.Map:
+		if depth >= maxDepth {
+			return
+		}
+
+		rv.Set(reflect.MakeMap(rv.Type()))
+		for n := 0; r.more(n); n++ {
+			k := reflect.New(rv.Type().Key()).Elem()
+			r.fill(k, depth+1)
+
+			v := reflect.New(rv.Type().Elem()).Elem()
+			r.fill(v, depth+1)
+
+			rv.SetMapIndex(k, v)
+		}
+
+	case reflect.Pointer:
+		if depth >= maxDepth || r.choice(4) == 0 {
+			return
+		}
+
+		ptr := reflect.New(rv.Type().Elem())
+		r.fill(ptr.Elem(), depth+1)
+		rv.Set(ptr)
+
+	case reflect.Struct:
+		for i := range rv.NumField() {
+			if field := rv.Field(i); field.CanSet() {
+				r.fill(field, depth+1)
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package check
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func testProperty(fn func(r *Rand) error) (string, bool) {
+	return checkProperty(1, 100, fn)
+}
+
+func TestProperty(t *testing.T) {
+	Property(t, func(r *Rand) bool {
+		a, b := r.Int(), r.Int()
+		return a+b == b+a
+	})
+
+	Property(t, func(r *Rand) error {
+		return nil
+	})
+}
+
+func TestCheckPropertyShrinksInts(t *testing.T) {
+	msg, ok := testProperty(func(r *Rand) error {
+		if r.Int() > 100 {
+			return errors.New("too big")
+		}
+
+		return nil
+	})
+
+	False(t, ok)
+	Contains(t, msg, "with seed 1 (set CHECK_PROPERTY_SEED=1 to reproduce)")
+	Contains(t, msg, dumpIndent+"Int(): int(101)\n")
+	Contains(t, msg, "Failure: too big")
+}
+
+func TestCheckPropertyShrinksSlices(t *testing.T) {
+	msg, ok := testProperty(func(r *Rand) error {
+		s := Arbitrary[[]int](r)
+		if slices.Contains(s, 5) {
+			return errors.New("contains 5")
+		}
+
+		return nil
+	})
+
+	False(t, ok)
+	Contains(t, msg, ""+
+		dumpIndent+"Arbitrary[[]int](): []int{\n"+
+		dumpIndent+dumpIndent+"int(5),\n"+
+		dumpIndent+"}\n")
+}
+
+func TestCheckPropertyShrinksStrings(t *testing.T) {
+	msg, ok := testProperty(func(r *Rand) error {
+		if len([]rune(r.Str())) > 2 {
+			return errors.New("too long")
+		}
+
+		return nil
+	})
+
+	False(t, ok)
+	Contains(t, msg, `Str(): "aaa"`)
+}
+
+func TestCheckPropertyShrinksStructs(t *testing.T) {
+	type point struct {
+		X, Y int
+		Tag  *string
+	}
+
+	msg, ok := testProperty(func(r *Rand) error {
+		p := Arbitrary[point](r)
+		if p.X != 0 && p.Tag != nil {
+			return errors.New("tagged")
+		}
+
+		return nil
+	})
+
+	False(t, ok)
+	Contains(t, msg, ""+
+		"Arbitrary[check.point](): check.point{\n"+
+		dumpIndent+dumpIndent+"X: int(1),\n"+
+		dumpIndent+dumpIndent+"Y: int(0),\n"+
+		dumpIndent+dumpIndent+`Tag: &"",`+"\n")
+}
+
+func TestCheckPropertyPanics(t *testing.T) {
+	msg, ok := testProperty(func(r *Rand) error {
+		m := Arbitrary[map[string]bool](r)
+		if len(m) > 1 {
+			panic("big map")
+		}
+
+		return nil
+	})
+
+	False(t, ok)
+	Contains(t, msg, "Failure: panic: big map")
+	Contains(t, msg, ""+
+		dumpIndent+"Arbitrary[map[string]bool](): map[string]bool{\n"+
+		dumpIndent+dumpIndent+`"": false,`+"\n"+
+		dumpIndent+dumpIndent+`"a": false,`+"\n"+
+		dumpIndent+"}\n")
+}
+
+func TestCheckPropertyPasses(t *testing.T) {
+	msg, ok := testProperty(func(r *Rand) error {
+		_ = r.Intn(10) + r.IntRange(-5, 5)
+		_ = r.Float64()
+		_ = r.Bool()
+		_ = Arbitrary[[3]complex64](r)
+		return nil
+	})
+
+	True(t, ok)
+	Equal(t, msg, "")
+}
+
+func TestRandRanges(t *testing.T) {
+	for seed := range uint64(100) {
+		r := &Rand{src: rand.New(rand.NewPCG(seed, 0))}
+
+		n := r.Intn(3)
+		True(t, n >= 0 && n < 3)
+
+		n = r.IntRange(-2, 2)
+		True(t, n >= -2 && n <= 2)
+
+		f := r.Float64()
+		True(t, f >= 0 && f < 1)
+	}
+
+	r := &Rand{}
+	Panics(t, func() { r.Intn(0) })
+	Panics(t, func() { r.IntRange(1, 0) })
+	Equal(t, r.IntRange(math.MinInt, math.MaxInt), math.MinInt)
+}
+
+func TestRandReplay(t *testing.T) {
+	gen := &Rand{src: rand.New(rand.NewPCG(3, 0))}
+	v := Arbitrary[map[string][]int](gen)
+
+	replay := &Rand{choices: gen.choices}
+	Equal(t, Arbitrary[map[string][]int](replay), v)
+
+	// Running out of choices gives zero values
+	Equal(t, replay.Int(), 0)
+	Equal(t, replay.Str(), "")
+}
+
+func TestShortlexLess(t *testing.T) {
+	True(t, shortlexLess([]uint64{9}, []uint64{0, 0}))
+	True(t, shortlexLess([]uint64{0, 1}, []uint64{0, 2}))
+	False(t, shortlexLess([]uint64{0, 2}, []uint64{0, 2}))
+}
@@ -0,0 +1,150 @@
+package check
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/peter-evans/patience"
+	"github.com/thatguystone/cog/callstack"
+)
+
+// reportPath is where failure records are appended, one JSON object per line.
+// Since `go test` runs each package in its own directory, relative paths are
+// relative to the package being tested.
+var reportPath = os.Getenv("CHECK_REPORT")
+
+type reportRecord struct {
+	Test    string       `json:"test,omitempty"`
+	File    string       `json:"file"`
+	Line    int          `json:"line"`
+	Check   string       `json:"check"`
+	Got     string       `json:"got,omitempty"`
+	Want    string       `json:"want,omitempty"`
+	Hunks   []reportHunk `json:"hunks,omitempty"`
+	Message string       `json:"message"`
+}
+
+type reportHunk struct {
+	Path  string   `json:"path,omitempty"`
+	Lines []string `json:"lines"`
+}
+
+// Report finishes the message of a failed check: it adds the [Table] case t
+// is running, if any, and appends a record of the failure to the CHECK_REPORT
+// file, if one is set. gotWant is either empty or the got and want values, in
+// that order. If the record can't be written, a note is added to msg.
+//
+// This is meant for wrappers generated by package checkgen; it must be called
+// directly from the check that failed.
+func Report(t any, check, msg string, gotWant ...any) string {
+	msg = tableCases.addCase(t, msg)
+
+	if reportPath == "" {
+		return msg
+	}
+
+	err := writeReport(newReportRecord(t, check, msg, gotWant))
+	if err != nil {
+		msg += "\n\n(failed to write CHECK_REPORT: " + err.Error() + ")"
+	}
+
+	return msg
+}
+
+func newReportRecord(t any, check, msg string, gotWant []any) reportRecord {
+	site := callstack.Caller(3).Frame() // newReportRecord, Report, check
+
+	rec := reportRecord{
+		File:    site.File(),
+		Line:    site.Line(),
+		Check:   check,
+		Message: msg,
+	}
+
+	if t, ok := t.(interface{ Name() string }); ok {
+		rec.Test = t.Name()
+	}
+
+	if len(gotWant) == 2 {
+		var (
+			st   = newDiffState()
+			g, e = st.dumpPair(gotWant[0], gotWant[1])
+		)
+
+		rec.Got = strings.Join(g.lines, "\n")
+		rec.Want = strings.Join(e.lines, "\n")
+		rec.Hunks = reportHunks(diffDumps(g, e))
+	}
+
+	return rec
+}
+
+func reportHunks(diffs []diffLine) []reportHunk {
+	var (
+		hunks []reportHunk
+		keep  = keepLines(diffs, Settings.DiffContext)
+	)
+
+	for i := 0; i < len(diffs); {
+		if !keep[i] {
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(diffs) && keep[end] {
+			end++
+		}
+
+		hunk := reportHunk{
+			Path:  hunkHeader(diffs[i:end]),
+			Lines: make([]string, 0, end-i),
+		}
+
+		for _, diff := range diffs[i:end] {
+			hunk.Lines = append(hunk.Lines, diff.String())
+		}
+
+		// With folding disabled, identical values are one big unchanged hunk
+		if hasChange(diffs[i:end]) {
+			hunks = append(hunks, hunk)
+		}
+
+		i = end
+	}
+
+	return hunks
+}
+
+func hasChange(diffs []diffLine) bool {
+	for _, diff := range diffs {
+		if diff.typ != patience.Equal {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeReport appends rec to the report with a single write, so that records
+// from tests running in parallel, even across packages, don't interleave.
+func writeReport(rec reportRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(reportPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(line, '\n'))
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,123 @@
+package check
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thatguystone/cog/callstack"
+)
+
+type reportRecorder struct {
+	exprsRecorder
+}
+
+func (reportRecorder) Name() string {
+	return "TestFake"
+}
+
+func withReport(t *testing.T) func() []reportRecord {
+	path := filepath.Join(t.TempDir(), "report.jsonl")
+
+	prev := reportPath
+	reportPath = path
+	t.Cleanup(func() { reportPath = prev })
+
+	return func() []reportRecord {
+		b, err := os.ReadFile(path)
+		MustNil(t, err)
+
+		var recs []reportRecord
+		for _, line := range strings.SplitAfter(string(b), "\n") {
+			if line == "" {
+				continue
+			}
+
+			var rec reportRecord
+			err := json.Unmarshal([]byte(line), &rec)
+			MustNil(t, err)
+
+			recs = append(recs, rec)
+		}
+
+		return recs
+	}
+}
+
+func TestReport(t *testing.T) {
+	read := withReport(t)
+
+	r := new(reportRecorder)
+	got := map[string]int{"a": 1}
+	Equal(r, got, map[string]int{"a": 2})
+	line := callstack.Self().Frame().Line() - 1
+	msg := r.msg
+	Truef(r, false, "fmt %d", 1)
+
+	recs := read()
+	MustEqual(t, len(recs), 2)
+
+	rec := recs[0]
+	Equal(t, rec.Test, "TestFake")
+	Equal(t, filepath.Base(rec.File), "report_test.go")
+	Equal(t, rec.Line, line)
+	Equal(t, rec.Check, "Equal")
+	Equal(t, rec.Got, dump(got, 0))
+	Equal(t, rec.Want, `map[string]int{`+"\n"+`    "a": int(2),`+"\n"+`}`)
+	Equal(t, rec.Hunks, []reportHunk{
+		{
+			Path: `["a"]`,
+			Lines: []string{
+				`  map[string]int{`,
+				`-     "a": int(1),`,
+				`+     "a": int(2),`,
+				`  }`,
+			},
+		},
+	})
+	Equal(t, "\n"+rec.Message, msg)
+
+	rec = recs[1]
+	Equal(t, rec.Check, "Truef")
+	Equal(t, rec.Message, "fmt 1\nExpected true")
+	Equal(t, rec.Got, "")
+	Equal(t, rec.Hunks, []reportHunk(nil))
+}
+
+func TestReportHunks(t *testing.T) {
+	var (
+		g = make([]int, 20)
+		e = make([]int, 20)
+	)
+
+	for i := range g {
+		g[i] = i
+		e[i] = i
+	}
+
+	e[2] = -1
+	e[15] = -1
+
+	hunks := reportHunks(diffDumps(dumpLines(g), dumpLines(e)))
+	Equal(t, len(hunks), 2)
+	Equal(t, hunks[0].Path, "[2]")
+	Equal(t, hunks[1].Path, "[15]")
+
+	withSettings(t, func(cfg *Config) { cfg.DiffContext = -1 })
+	hunks = reportHunks(diffDumps(dumpLines(g), dumpLines(e)))
+	Equal(t, len(hunks), 1)
+
+	hunks = reportHunks(diffDumps(dumpLines(g), dumpLines(g)))
+	Equal(t, len(hunks), 0)
+}
+
+func TestReportWriteError(t *testing.T) {
+	withReport(t)
+	reportPath = t.TempDir() // Can't open a directory for writing
+
+	r := new(exprsRecorder)
+	False(r, true)
+	Contains(t, r.msg, "failed to write CHECK_REPORT")
+}
@@ -0,0 +1,72 @@
+package check
+
+import (
+	"os"
+	"strconv"
+)
+
+// Config controls how checks render failure messages
+type Config struct {
+	// Number of unchanged lines to show around each change in a diff. Longer
+	// runs of unchanged lines are folded into a single marker. Set to a
+	// negative value to disable folding.
+	DiffContext int
+
+	// Limits on how much of a value is dumped; 0 means unlimited. These are
+	// all ignored when the environment variable CHECK_FULL_DUMP=1 is set, and
+	// by diffs where they would hide every difference.
+	MaxDepth int // Max nesting depth of arrays, slices, maps, and structs
+	MaxElems int // Max number of elements shown per array, slice, and map
+	MaxLen   int // Max number of bytes shown per string and []byte
+
+	// Byte slices and arrays at least this long are shown like `xxd`, with
+	// offsets and an ASCII gutter. Set to 0 to always show them as a list of
+	// bytes.
+	HexdumpMin int
+
+	// Show []byte holding printable UTF-8 as a string, eg. []uint8("text")
+	BytesAsText bool
+
+	// Leave unexported struct fields out of dumps entirely
+	HideUnexported bool
+
+	// Replace the addresses of chans and unsafe pointers in dumps, and of the
+	// pointers, maps, and chans in [Same] and [NotSame] messages, with ids
+	// assigned in the order they're found, so output is the same on every run.
+	Deterministic bool
+
+	// Number of random inputs [Property] tries before passing
+	PropertyRuns int
+
+	// Compare values that have an `Equal(T) bool` method, anywhere in a value,
+	// by calling it rather than comparing them field by field. Values in
+	// unexported struct fields are always compared field by field, since
+	// their methods can't be called without unsafe.
+	EqualMethods bool
+
+	// When [AllocsAtMost] fails, run fn again with every allocation profiled
+	// to show where they come from. This briefly sets the process-wide
+	// [runtime.MemProfileRate], so allocations made by tests running in
+	// parallel are recorded too, and `go test -memprofile` output is skewed.
+	AllocSites bool
+}
+
+// Settings is the Config used by all checks. It isn't synchronized, so only
+// change it from TestMain or an init func, before any checks run.
+var Settings = Config{
+	DiffContext: 3,
+	MaxDepth:    16,
+	MaxElems:    100,
+	MaxLen:      1024,
+	HexdumpMin:  64,
+
+	PropertyRuns: 100,
+	EqualMethods: true,
+}
+
+var fullDump = envBool("CHECK_FULL_DUMP")
+
+func envBool(key string) bool {
+	b, _ := strconv.ParseBool(os.Getenv(key))
+	return b
+}
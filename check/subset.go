@@ -0,0 +1,121 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/thatguystone/cog/textwrap"
+)
+
+func checkSubset(g, e any) (string, bool) {
+	return checkSubsetOf(g, e, "got", "subset")
+}
+
+func checkSuperset(g, e any) (string, bool) {
+	return checkSubsetOf(e, g, "want", "superset")
+}
+
+// checkSubsetOf checks that sub is a subset of super. subName is the name of
+// sub, either "got" or "want", and rel is how g relates to e.
+func checkSubsetOf(sub, super any, subName, rel string) (string, bool) {
+	var (
+		sv = reflect.ValueOf(sub)
+		pv = reflect.ValueOf(super)
+		b  strings.Builder
+	)
+
+	fmt.Fprintf(&b, "Expected got to be a %s of want:\n", rel)
+
+	switch {
+	case isList(sv) && isList(pv) && sv.Type().Elem() == pv.Type().Elem():
+		missing := listMissing(sv, pv)
+		if len(missing) == 0 {
+			return "", true
+		}
+
+		fmt.Fprintf(&b, "%sOnly in %s:\n", dumpIndent, subName)
+		for _, i := range missing {
+			entry := fmt.Sprintf("[%d]: %s,\n", i, dump(sv.Index(i).Interface(), 0))
+			b.WriteString(textwrap.IndentFunc(
+				entry,
+				dumpIndent+dumpIndent,
+				func(string) bool { return true }))
+		}
+
+	case sv.Kind() == reflect.Map && sv.Type() == pv.Type():
+		g, e := mapMissing(sv, pv)
+		if subName != "got" {
+			g, e = e, g
+		}
+
+		if g.Len() == 0 && e.Len() == 0 {
+			return "", true
+		}
+
+		writeMapDiff(&b, g, e, dumpIndent, newDiffState())
+
+	default:
+		return fmt.Sprintf(
+			"Expected slices, arrays, or maps of the same type, got: %s and %s",
+			typeChain(sub),
+			typeChain(super),
+		), false
+	}
+
+	return strings.TrimSuffix(b.String(), "\n"), false
+}
+
+func isList(rv reflect.Value) bool {
+	return rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
+}
+
+// listMissing gets the indexes of the elements of sub that aren't in super,
+// counting duplicates
+func listMissing(sub, super reflect.Value) []int {
+	var (
+		missing []int
+		used    = make([]bool, super.Len())
+	)
+
+	for i := range sub.Len() {
+		el := sub.Index(i).Interface()
+
+		found := false
+		for j := range super.Len() {
+			if !used[j] && deepEqual(el, super.Index(j).Interface()) {
+				used[j] = true
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, i)
+		}
+	}
+
+	return missing
+}
+
+// mapMissing gets the entries of sub that are missing from or differ in
+// super, along with super's entries for the same keys
+func mapMissing(sub, super reflect.Value) (subOnly, superOnly reflect.Value) {
+	subOnly = reflect.MakeMap(sub.Type())
+	superOnly = reflect.MakeMap(sub.Type())
+
+	iter := sub.MapRange()
+	for iter.Next() {
+		pv := super.MapIndex(iter.Key())
+		switch {
+		case !pv.IsValid():
+			subOnly.SetMapIndex(iter.Key(), iter.Value())
+
+		case !deepEqual(iter.Value().Interface(), pv.Interface()):
+			subOnly.SetMapIndex(iter.Key(), iter.Value())
+			superOnly.SetMapIndex(iter.Key(), pv)
+		}
+	}
+
+	return subOnly, superOnly
+}
@@ -0,0 +1,68 @@
+package check
+
+import "testing"
+
+func TestCheckSubset(t *testing.T) {
+	testCheck(checkSubset([]int{1, 2}, []int{3, 2, 1}))(t, true)
+	testCheck(checkSubset([]int{}, []int{1}))(t, true)
+	testCheck(checkSubset([2]int{1, 1}, []int{1, 2, 1}))(t, true)
+	testCheck(checkSubset([]int{1, 1}, []int{1, 2}))(t, false)
+	testCheck(checkSubset([]int{1}, []string{"1"}))(t, false)
+	testCheck(checkSubset(1, 1))(t, false)
+
+	testCheck(checkSubset(map[int]int{1: 1}, map[int]int{1: 1, 2: 2}))(t, true)
+	testCheck(checkSubset(map[int]int{1: 2}, map[int]int{1: 1}))(t, false)
+	testCheck(checkSubset(map[int]int{1: 1}, map[int]int8{1: 1}))(t, false)
+
+	msg, _ := checkSubset([]int{1, 3, 1, 4}, []int{1, 2})
+	Equal(t, msg, ""+
+		"Expected got to be a subset of want:\n"+
+		"    Only in got:\n"+
+		"        [1]: int(3),\n"+
+		"        [2]: int(1),\n"+
+		"        [3]: int(4),",
+	)
+
+	msg, _ = checkSubset(
+		map[string]int{"same": 1, "diff": 1, "got": 1},
+		map[string]int{"same": 1, "diff": 2, "want": 1},
+	)
+	Equal(t, msg, ""+
+		"Expected got to be a subset of want:\n"+
+		"    Only in got:\n"+
+		`        "got": int(1),`+"\n"+
+		"    Different values:\n"+
+		`        "diff":`+"\n"+
+		"            - int(1)\n"+
+		"            + int(2)",
+	)
+}
+
+func TestCheckSuperset(t *testing.T) {
+	testCheck(checkSuperset([]int{3, 2, 1}, []int{1, 2}))(t, true)
+	testCheck(checkSuperset([]int{1, 2}, []int{1, 1}))(t, false)
+
+	testCheck(checkSuperset(map[int]int{1: 1, 2: 2}, map[int]int{1: 1}))(t, true)
+	testCheck(checkSuperset(map[int]int{1: 1}, map[int]int{1: 2}))(t, false)
+
+	msg, _ := checkSuperset([]int{1, 2}, []int{2, 5})
+	Equal(t, msg, ""+
+		"Expected got to be a superset of want:\n"+
+		"    Only in want:\n"+
+		"        [1]: int(5),",
+	)
+
+	msg, _ = checkSuperset(
+		map[string]int{"same": 1, "diff": 1, "got": 1},
+		map[string]int{"same": 1, "diff": 2, "want": 1},
+	)
+	Equal(t, msg, ""+
+		"Expected got to be a superset of want:\n"+
+		"    Only in want:\n"+
+		`        "want": int(1),`+"\n"+
+		"    Different values:\n"+
+		`        "diff":`+"\n"+
+		"            - int(1)\n"+
+		"            + int(2)",
+	)
+}
@@ -0,0 +1,165 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thatguystone/cog/callstack"
+)
+
+// TableCase is a case run by [Table]; create it with [Case]
+type TableCase[T any] struct {
+	Case T
+	pc   callstack.PC
+}
+
+// Case wraps tc for [Table], remembering the line it's declared on so that
+// failures point back to it
+func Case[T any](tc T) TableCase[T] {
+	return TableCase[T]{
+		Case: tc,
+		pc:   callstack.Caller(1),
+	}
+}
+
+// Table runs fn for each case as a subtest. Cases are usually structs, and
+// these fields are used, if present:
+//
+//   - Name string: the name of the subtest; otherwise, it's a compact dump of
+//     the case
+//   - Skip bool: skip the case
+//   - Only bool: if any case sets it, skip every case that doesn't
+//
+// Failures reported by checks inside fn include a dump of the case and the
+// line it's declared on.
+func Table[T any](t *testing.T, cases []TableCase[T], fn func(t *testing.T, tc T)) {
+	t.Helper()
+
+	only := false
+	for _, tc := range cases {
+		only = only || caseFlag(tc.Case, "Only")
+	}
+
+	for _, tc := range cases {
+		t.Run(caseName(tc.Case), func(t *testing.T) {
+			switch {
+			case caseFlag(tc.Case, "Skip"):
+				t.Skip("case has Skip set")
+			case only && !caseFlag(tc.Case, "Only"):
+				t.Skip("another case has Only set")
+			}
+
+			tableCases.set(t, tableCaseMsg(tc.Case, tc.pc))
+			t.Cleanup(func() { tableCases.delete(t) })
+
+			fn(t, tc.Case)
+		})
+	}
+}
+
+// caseField gets the named field of tc, which may be a struct or a pointer to
+// one
+func caseField(tc any, name string) reflect.Value {
+	rv := reflect.ValueOf(tc)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	return rv.FieldByName(name)
+}
+
+func caseFlag(tc any, name string) bool {
+	field := caseField(tc, name)
+	return field.Kind() == reflect.Bool && field.Bool()
+}
+
+func caseName(tc any) string {
+	field := caseField(tc, "Name")
+	if field.Kind() == reflect.String && field.String() != "" {
+		return field.String()
+	}
+
+	return compactDump(tc)
+}
+
+// compactDump dumps v on a single line
+func compactDump(v any) string {
+	var parts []string
+	for _, line := range dumpLines(v).lines {
+		line = strings.TrimSpace(line)
+
+		n := len(parts)
+		switch {
+		case n > 0 && strings.HasPrefix(line, "}"):
+			parts[n-1] = strings.TrimSuffix(parts[n-1], ",") + line
+		case n > 0 && strings.HasSuffix(parts[n-1], "{"):
+			parts[n-1] += line
+		default:
+			parts = append(parts, line)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func tableCaseMsg(tc any, pc callstack.PC) string {
+	var b strings.Builder
+	b.WriteString("Table case")
+
+	if pc != 0 {
+		frame := pc.Frame()
+		fmt.Fprintf(&b, " at %s:%d", frame.FileName(), frame.Line())
+	}
+
+	b.WriteString(":\n")
+	b.WriteString(dump(tc, 1))
+
+	return b.String()
+}
+
+// tableCases holds the case message for each test running a table case
+var tableCases = tableCaseRegistry{
+	msgs: map[any]string{},
+}
+
+type tableCaseRegistry struct {
+	mtx  sync.RWMutex
+	msgs map[any]string
+}
+
+func (reg *tableCaseRegistry) set(t any, msg string) {
+	reg.mtx.Lock()
+	defer reg.mtx.Unlock()
+
+	reg.msgs[t] = msg
+}
+
+func (reg *tableCaseRegistry) delete(t any) {
+	reg.mtx.Lock()
+	defer reg.mtx.Unlock()
+
+	delete(reg.msgs, t)
+}
+
+// addCase adds the table case being run by t, if any, to msg
+func (reg *tableCaseRegistry) addCase(t any, msg string) string {
+	if t == nil || !reflect.TypeOf(t).Comparable() {
+		return msg
+	}
+
+	reg.mtx.RLock()
+	defer reg.mtx.RUnlock()
+
+	if caseMsg, ok := reg.msgs[t]; ok {
+		msg += "\n\n" + caseMsg
+	}
+
+	return msg
+}
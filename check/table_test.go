@@ -0,0 +1,100 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/thatguystone/cog/callstack"
+)
+
+func TestTable(t *testing.T) {
+	type tc struct {
+		Name string
+		In   int
+		Want int
+	}
+
+	var ran []string
+
+	Table(
+		t,
+		[]TableCase[tc]{
+			Case(tc{Name: "One", In: 1, Want: 2}),
+			Case(tc{In: 2, Want: 4}),
+		},
+		func(t *testing.T, tc tc) {
+			ran = append(ran, t.Name())
+			Equal(t, tc.In*2, tc.Want)
+
+			msg := Report(t, "Test", "msg")
+			Contains(t, msg, "msg\n\nTable case at table_test.go:")
+			Contains(t, msg, dump(tc, 1))
+		},
+	)
+
+	Equal(t, ran, []string{
+		"TestTable/One",
+		"TestTable/check.tc{Name:_\"\",_In:_int(2),_Want:_int(4)}",
+	})
+
+	c := Case(tc{})
+	line := callstack.Self().Frame().Line() - 1
+	Contains(t, tableCaseMsg(c.Case, c.pc), fmt.Sprintf("table_test.go:%d:", line))
+
+	NotContains(t, Report(t, "Test", "msg"), "Table case")
+}
+
+func TestTableSkipOnly(t *testing.T) {
+	type tc struct {
+		Skip, Only bool
+		ID         int
+	}
+
+	var ran []int
+	t.Run("Skip", func(t *testing.T) {
+		Table(
+			t,
+			[]TableCase[tc]{
+				Case(tc{ID: 1}),
+				Case(tc{ID: 2, Skip: true}),
+			},
+			func(t *testing.T, tc tc) { ran = append(ran, tc.ID) },
+		)
+	})
+
+	Equal(t, ran, []int{1})
+
+	ran = nil
+	t.Run("Only", func(t *testing.T) {
+		Table(
+			t,
+			[]TableCase[*tc]{
+				Case(&tc{ID: 1}),
+				Case(&tc{ID: 2, Only: true}),
+				{Case: &tc{ID: 3, Only: true}},
+			},
+			func(t *testing.T, tc *tc) { ran = append(ran, tc.ID) },
+		)
+	})
+
+	Equal(t, ran, []int{2, 3})
+}
+
+func TestCompactDump(t *testing.T) {
+	Equal(t, compactDump(1), "int(1)")
+	Equal(t, compactDump([]int{}), "[]int{}")
+	Equal(t, compactDump([]int{1, 2}), "[]int{int(1), int(2)}")
+	Equal(
+		t,
+		compactDump(map[string][]int{"a": {1}, "b": nil}),
+		`map[string][]int{"a": []int{int(1)}, "b": []int(nil)}`,
+	)
+	Equal(t, strings.Count(compactDump("a\nb\n"), "\n"), 0)
+}
+
+func TestTableCaseMsg(t *testing.T) {
+	Equal(t, tableCaseMsg(1, 0), "Table case:\n"+dumpIndent+"int(1)")
+	Equal(t, tableCases.addCase(nil, "msg"), "msg")
+	Equal(t, tableCases.addCase([]int{}, "msg"), "msg")
+}
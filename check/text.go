@@ -0,0 +1,55 @@
+package check
+
+import (
+	"strings"
+
+	"github.com/thatguystone/cog/textwrap"
+)
+
+// normalizeText normalizes text for comparison: line endings become "\n",
+// trailing whitespace is removed from every line, and trailing newlines are
+// removed entirely. If isLiteral, s is treated as text written as an indented
+// raw string literal, so it's also dedented and a leading newline is removed.
+func normalizeText(s string, isLiteral bool) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	if isLiteral {
+		s = strings.TrimPrefix(s, "\n")
+		s = textwrap.Dedent(s)
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+func textMsg(g, e string) (string, bool) {
+	if g == e {
+		return "", true
+	}
+
+	var b strings.Builder
+	b.WriteString("Expected text to be equal:\n")
+	writeDiff(
+		&b,
+		diffDumps(
+			rawLines(strings.Split(g, "\n")),
+			rawLines(strings.Split(e, "\n")),
+		),
+		Settings.DiffContext,
+		dumpIndent,
+	)
+
+	return strings.TrimSuffix(b.String(), "\n"), false
+}
+
+func checkText(g, e string) (string, bool) {
+	return textMsg(normalizeText(g, false), normalizeText(e, true))
+}
+
+func checkTextDedent(g, e string) (string, bool) {
+	return textMsg(normalizeText(g, true), normalizeText(e, true))
+}
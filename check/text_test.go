@@ -0,0 +1,42 @@
+package check
+
+import "testing"
+
+func TestNormalizeText(t *testing.T) {
+	Equal(t, normalizeText("a \r\nb\t\n\n", false), "a\nb")
+	Equal(t, normalizeText("\n\ta\n\t\tb\n\t", false), "\n\ta\n\t\tb")
+	Equal(t, normalizeText("\n\ta\n\t\tb\n\t", true), "a\n\tb")
+}
+
+func TestCheckText(t *testing.T) {
+	testCheck(checkText("a\n  b\n", `
+		a
+		  b
+	`))(t, true)
+
+	testCheck(checkText("a\r\nb  \r\n", "a\nb"))(t, true)
+	testCheck(checkText("  a\n", "\n\ta\n"))(t, false)
+
+	msg, ok := checkText("a\nb\nc\n", `
+		a
+		B
+		c
+	`)
+	False(t, ok)
+	Equal(t, msg, ""+
+		"Expected text to be equal:\n"+
+		"      a\n"+
+		"    - b\n"+
+		"    + B\n"+
+		"      c",
+	)
+}
+
+func TestCheckTextDedent(t *testing.T) {
+	testCheck(checkTextDedent("\n    a\n      b\n", `
+		a
+		  b
+	`))(t, true)
+
+	testCheck(checkTextDedent("  a\n", "b"))(t, false)
+}
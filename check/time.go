@@ -0,0 +1,70 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// writeTimes writes a labeled list of times, aligned
+func writeTimes(b *strings.Builder, labels []string, times []time.Time) {
+	width := 0
+	for _, label := range labels {
+		width = max(width, len(label))
+	}
+
+	for i, label := range labels {
+		fmt.Fprintf(
+			b,
+			"\n%s%-*s %s",
+			dumpIndent,
+			width+1,
+			label+":",
+			times[i].Format(time.RFC3339Nano),
+		)
+	}
+}
+
+func timesMsg(header string, g, e time.Time) string {
+	var b strings.Builder
+	b.WriteString(header)
+	writeTimes(&b, []string{"got", "want"}, []time.Time{g, e})
+	fmt.Fprintf(&b, "\n%sdelta: %s", dumpIndent, g.Sub(e))
+	return b.String()
+}
+
+func checkTimeEqual(g, e time.Time) (string, bool) {
+	if g.Equal(e) {
+		return "", true
+	}
+
+	return timesMsg("Expected times to be equal:", g, e), false
+}
+
+func checkWithinDuration(g, e time.Time, d time.Duration) (string, bool) {
+	delta := g.Sub(e)
+	if delta >= -d && delta <= d {
+		return "", true
+	}
+
+	return timesMsg(fmt.Sprintf("Expected times to be within %s of each other:", d), g, e), false
+}
+
+func checkTimeBetween(g, start, end time.Time) (string, bool) {
+	var delta string
+	switch {
+	case g.Before(start):
+		delta = fmt.Sprintf("before start by %s", start.Sub(g))
+	case g.After(end):
+		delta = fmt.Sprintf("after end by %s", g.Sub(end))
+	default:
+		return "", true
+	}
+
+	var b strings.Builder
+	b.WriteString("Expected time to be between start and end:")
+	writeTimes(&b, []string{"got", "start", "end"}, []time.Time{g, start, end})
+	fmt.Fprintf(&b, "\n%sgot is %s", dumpIndent, delta)
+
+	return b.String(), false
+}
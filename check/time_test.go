@@ -0,0 +1,61 @@
+package check
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckTimeEqual(t *testing.T) {
+	var (
+		now = time.Now()
+		utc = now.UTC().Round(0)
+	)
+
+	testCheck(checkTimeEqual(now, utc))(t, true)
+	testCheck(checkEqual(now, utc))(t, true)
+	testCheck(checkTimeEqual(now, now.Add(1)))(t, false)
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	msg, _ := checkTimeEqual(at, at.Add(1500*time.Millisecond))
+	Equal(t, msg, ""+
+		"Expected times to be equal:\n"+
+		"    got:  2024-01-02T03:04:05.000000006Z\n"+
+		"    want: 2024-01-02T03:04:06.500000006Z\n"+
+		"    delta: -1.5s",
+	)
+}
+
+func TestCheckWithinDuration(t *testing.T) {
+	now := time.Now()
+
+	testCheck(checkWithinDuration(now, now.Add(time.Second), time.Second))(t, true)
+	testCheck(checkWithinDuration(now, now.Add(-time.Second), time.Second))(t, true)
+	testCheck(checkWithinDuration(now, now.Add(time.Second+1), time.Second))(t, false)
+
+	msg, _ := checkWithinDuration(now, now.Add(2*time.Second), time.Second)
+	Contains(t, msg, "Expected times to be within 1s of each other:\n")
+	Contains(t, msg, "delta: -2s")
+}
+
+func TestCheckTimeBetween(t *testing.T) {
+	var (
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		end   = start.Add(time.Hour)
+	)
+
+	testCheck(checkTimeBetween(start, start, end))(t, true)
+	testCheck(checkTimeBetween(end, start, end))(t, true)
+	testCheck(checkTimeBetween(start.Add(-1), start, end))(t, false)
+
+	msg, _ := checkTimeBetween(end.Add(time.Minute), start, end)
+	Equal(t, msg, ""+
+		"Expected time to be between start and end:\n"+
+		"    got:   2024-01-01T01:01:00Z\n"+
+		"    start: 2024-01-01T00:00:00Z\n"+
+		"    end:   2024-01-01T01:00:00Z\n"+
+		"    got is after end by 1m0s",
+	)
+
+	msg, _ = checkTimeBetween(start.Add(-time.Second), start, end)
+	Contains(t, msg, "got is before start by 1s")
+}
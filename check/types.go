@@ -0,0 +1,196 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// IsType checks that v holds a T, returning it, or the zero value if it
+// doesn't. T may be an interface, in which case this is like [Implements].
+func IsType[T any](t Error, v any) T {
+	got, msg, ok := checkIsType[T](v)
+	if !ok {
+		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "IsType", msg)
+		t.Error("\n" + msg)
+	}
+
+	return got
+}
+
+// MustBeType is like [IsType], but fails the test immediately
+func MustBeType[T any](t Fatal, v any) T {
+	got, msg, ok := checkIsType[T](v)
+	if !ok {
+		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "MustBeType", msg)
+		t.Fatal("\n" + msg)
+	}
+
+	return got
+}
+
+// Implements checks that v implements the interface I
+func Implements[I any](t Error, v any) bool {
+	if msg, ok := checkImplements[I](v); !ok {
+		t.Helper()
+		msg = ExprMsg(msg, v)
+		msg = Report(t, "Implements", msg)
+		t.Error("\n" + msg)
+		return false
+	}
+
+	return true
+}
+
+func checkIsType[T any](v any) (T, string, bool) {
+	got, ok := v.(T)
+	if ok {
+		return got, "", true
+	}
+
+	return got, fmt.Sprintf(
+		"Expected a value of type %s, got: %s\n%s",
+		typeName(reflect.TypeFor[T]()),
+		typeChain(v),
+		dump(v, 1),
+	), false
+}
+
+func checkImplements[I any](v any) (string, bool) {
+	it := reflect.TypeFor[I]()
+	if it.Kind() != reflect.Interface {
+		return fmt.Sprintf("%s is not an interface", typeName(it)), false
+	}
+
+	rt := reflect.TypeOf(v)
+	if rt != nil && rt.Implements(it) {
+		return "", true
+	}
+
+	msg := fmt.Sprintf(
+		"Expected a value implementing %s, got: %s",
+		typeName(it),
+		typeChain(v),
+	)
+
+	if rt != nil && rt.Kind() != reflect.Pointer && reflect.PointerTo(rt).Implements(it) {
+		msg += fmt.Sprintf(" (but %s does)", typeName(reflect.PointerTo(rt)))
+	}
+
+	return msg, false
+}
+
+// typeChain describes the dynamic type of v, following pointers and
+// interfaces, eg. `*any -> any -> *errors.errorString -> errors.errorString`
+func typeChain(v any) string {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return "nil"
+	}
+
+	var chain []string
+	for {
+		chain = append(chain, typeName(rv.Type()))
+
+		switch rv.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			if rv.IsNil() {
+				chain[len(chain)-1] += "(nil)"
+				return strings.Join(chain, " -> ")
+			}
+
+			rv = rv.Elem()
+
+		default:
+			return strings.Join(chain, " -> ")
+		}
+	}
+}
+
+// pointerOf gets the address v points to, if it's a pointer-like value
+func pointerOf(v any) (uintptr, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Chan, reflect.Map, reflect.UnsafePointer:
+		return rv.Pointer(), true
+	default:
+		return 0, false
+	}
+}
+
+// fmtAddrs formats addresses the way dumps do, so that they get stable ids
+// with [Config.Deterministic]
+func fmtAddrs(ptrs ...uintptr) []string {
+	var (
+		d     = newDumper(0)
+		addrs = make([]string, len(ptrs))
+	)
+
+	for i, ptr := range ptrs {
+		d.buf.Reset()
+		d.writeAddr(uint64(ptr))
+		addrs[i] = d.buf.String()
+	}
+
+	return addrs
+}
+
+func checkSame(g, e any) (string, bool) {
+	gp, gok := pointerOf(g)
+	ep, eok := pointerOf(e)
+
+	switch {
+	case !gok || !eok:
+		return fmt.Sprintf(
+			"Expected pointers, chans, or maps, got: %s and %s",
+			typeChain(g),
+			typeChain(e),
+		), false
+
+	case reflect.TypeOf(g) != reflect.TypeOf(e):
+		return fmt.Sprintf(
+			"Expected the same pointer, but types differ: %s and %s",
+			typeName(reflect.TypeOf(g)),
+			typeName(reflect.TypeOf(e)),
+		), false
+
+	case gp != ep:
+		addrs := fmtAddrs(gp, ep)
+		return fmt.Sprintf(""+
+			"Expected the same pointer:\n"+
+			"%sgot:  %s(%s)\n"+
+			"%swant: %s(%s)",
+			dumpIndent, typeName(reflect.TypeOf(g)), addrs[0],
+			dumpIndent, typeName(reflect.TypeOf(e)), addrs[1],
+		), false
+	}
+
+	return "", true
+}
+
+func checkNotSame(g, e any) (string, bool) {
+	gp, gok := pointerOf(g)
+	ep, eok := pointerOf(e)
+
+	switch {
+	case !gok || !eok:
+		return fmt.Sprintf(
+			"Expected pointers, chans, or maps, got: %s and %s",
+			typeChain(g),
+			typeChain(e),
+		), false
+
+	case reflect.TypeOf(g) == reflect.TypeOf(e) && gp == ep:
+		return fmt.Sprintf(
+			"Expected different pointers, but both are %s(%s)",
+			typeName(reflect.TypeOf(g)),
+			fmtAddrs(gp)[0],
+		), false
+	}
+
+	return "", true
+}
@@ -0,0 +1,112 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+type testPtrStringer struct{}
+
+func (*testPtrStringer) String() string { return "" }
+
+func TestIsType(t *testing.T) {
+	var v any = "str"
+	Equal(t, IsType[string](t, v), "str")
+	Equal(t, MustBeType[fmt.Stringer](t, &testPtrStringer{}), fmt.Stringer(&testPtrStringer{}))
+
+	got, msg, ok := checkIsType[int](v)
+	testCheck(msg, ok)(t, false)
+	Equal(t, got, 0)
+	Equal(t, msg, "Expected a value of type int, got: string\n"+dumpIndent+`"str"`)
+
+	_, msg, ok = checkIsType[any](nil)
+	testCheck(msg, ok)(t, false)
+	Contains(t, msg, "Expected a value of type any, got: nil\n")
+}
+
+func TestImplements(t *testing.T) {
+	Implements[io.Reader](t, strings.NewReader(""))
+
+	testCheck(checkImplements[fmt.Stringer](&testPtrStringer{}))(t, true)
+	testCheck(checkImplements[io.Reader](1))(t, false)
+	testCheck(checkImplements[io.Reader](nil))(t, false)
+	testCheck(checkImplements[int](1))(t, false)
+
+	msg, _ := checkImplements[fmt.Stringer](testPtrStringer{})
+	Equal(t, msg, ""+
+		"Expected a value implementing fmt.Stringer, got: check.testPtrStringer "+
+		"(but *check.testPtrStringer does)")
+
+	msg, _ = checkImplements[int](1)
+	Equal(t, msg, "int is not an interface")
+}
+
+func TestTypeChain(t *testing.T) {
+	var (
+		err   = errors.New("err")
+		iface any
+	)
+
+	Equal(t, typeChain(nil), "nil")
+	Equal(t, typeChain(1), "int")
+	Equal(t, typeChain(&err), "*error -> error -> *errors.errorString -> errors.errorString")
+	Equal(t, typeChain(&iface), "*any -> any(nil)")
+	Equal(t, typeChain((*int)(nil)), "*int(nil)")
+}
+
+func TestCheckSame(t *testing.T) {
+	var (
+		a, b = new(int), new(int)
+		m    = map[int]int{}
+		ch   = make(chan int)
+	)
+
+	testCheck(checkSame(a, a))(t, true)
+	testCheck(checkSame(m, m))(t, true)
+	testCheck(checkSame(ch, ch))(t, true)
+	testCheck(checkSame(a, b))(t, false)
+	testCheck(checkSame(a, new(int8)))(t, false)
+	testCheck(checkSame(1, 1))(t, false)
+
+	msg, _ := checkSame(a, b)
+	Equal(t, msg, fmt.Sprintf(""+
+		"Expected the same pointer:\n"+
+		"    got:  *int(%p)\n"+
+		"    want: *int(%p)",
+		a,
+		b,
+	))
+
+	t.Run("Deterministic", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.Deterministic = true })
+
+		msg, _ := checkSame(a, b)
+		Equal(t, msg, ""+
+			"Expected the same pointer:\n"+
+			"    got:  *int(#1)\n"+
+			"    want: *int(#2)",
+		)
+	})
+}
+
+func TestCheckNotSame(t *testing.T) {
+	var a, b = new(int), new(int)
+
+	testCheck(checkNotSame(a, b))(t, true)
+	testCheck(checkNotSame(a, new(int8)))(t, true)
+	testCheck(checkNotSame(a, a))(t, false)
+	testCheck(checkNotSame(1, 1))(t, false)
+
+	msg, _ := checkNotSame(a, a)
+	Equal(t, msg, fmt.Sprintf("Expected different pointers, but both are *int(%p)", a))
+
+	t.Run("Deterministic", func(t *testing.T) {
+		withSettings(t, func(cfg *Config) { cfg.Deterministic = true })
+
+		msg, _ := checkNotSame(a, a)
+		Equal(t, msg, "Expected different pointers, but both are *int(#1)")
+	})
+}
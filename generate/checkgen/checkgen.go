@@ -0,0 +1,189 @@
+// Package checkgen generates the X, Xf, MustX, and MustXf wrappers that
+// package check exposes for every check, so that other packages can declare
+// their own checks with the same semantics.
+//
+// A check is an unexported func returning a failure message and whether the
+// check passed, eg. `func checkX(...) (string, bool)`. Wrappers are generated
+// from a .cmd.go file, see package generate:
+//
+//	//go:build generate
+//
+//	//go:generate go run $GOFILE
+//
+//	package main
+//
+//	func main() {
+//		b := generate.New()
+//		checkgen.Generate(b, checkgen.Func{
+//			Name:  "Positive",
+//			Args:  "n int",
+//			Check: "checkPositive(n)",
+//			Doc:   "Check that n is positive.",
+//		})
+//		b.WriteFile()
+//	}
+package checkgen
+
+import (
+	"go/ast"
+	"go/parser"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/thatguystone/cog/assert"
+	"github.com/thatguystone/cog/generate"
+)
+
+const checkPath = "github.com/thatguystone/cog/check"
+
+// Func describes a check to generate wrappers for
+type Func struct {
+	Name  string // Name of the wrappers, eg. "Equal" generates Equal and Equalf
+	Must  string // Name of the Must wrappers, if different from Name
+	Args  string // Params of the check, after t
+	Check string // Expression that calls the check with Args
+	Doc   string // Doc comment for the wrappers
+
+	// Names of the params holding the got and want values, if any, to include
+	// in CHECK_REPORT records
+	Got  string
+	Want string
+
+	// Comma-separated names of params to leave out of failure messages when
+	// showing the source expressions of args, eg. ones with noisy dumps
+	NoExprs string
+}
+
+// Params gets the names of the params declared in Args, comma-separated
+func (fn Func) Params() string {
+	expr := assert.Must(parser.ParseExpr("func(" + fn.Args + ")"))
+
+	var names []string
+	for _, field := range expr.(*ast.FuncType).Params.List {
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// GotWant gets the got and want args to pass to [check.Report]
+func (fn Func) GotWant() string {
+	if fn.Got == "" {
+		return ""
+	}
+
+	return ", " + fn.Got + ", " + fn.Want
+}
+
+// Generate writes the wrappers for every func to b
+func Generate(b *generate.Buffer, funcs ...Func) {
+	b.WriteString("\n//gocovr:skip-file\n")
+
+	qual := ""
+	if b.ImportPath() != checkPath {
+		qual = "check."
+		b.WriteString("\nimport \"" + checkPath + "\"\n")
+	} else {
+		b.WriteString("\nimport()\n")
+	}
+
+	write(b, qual, funcs)
+}
+
+// tmplData is what templates are executed with
+type tmplData struct {
+	Func
+	Qual string
+}
+
+// ExprArgs gets the args to pass to [check.ExprMsg]
+func (data tmplData) ExprArgs() string {
+	var (
+		params  = strings.Split(data.Params(), ", ")
+		noExprs = strings.Split(data.NoExprs, ",")
+	)
+
+	for i, param := range params {
+		for _, noExpr := range noExprs {
+			if param == strings.TrimSpace(noExpr) {
+				params[i] = data.Qual + "NoExpr"
+			}
+		}
+	}
+
+	return strings.Join(params, ", ")
+}
+
+func write(w io.Writer, qual string, funcs []Func) {
+	for _, fn := range funcs {
+		data := tmplData{
+			Func: fn,
+			Qual: qual,
+		}
+
+		for _, tmpl := range templates {
+			err := tmpl.Execute(w, data)
+			assert.Nil(err)
+		}
+	}
+}
+
+func newTemplate(tmpl string) *template.Template {
+	return assert.Must(template.New("checkgen").Parse(tmpl))
+}
+
+var templates = []*template.Template{
+	newTemplate(`
+		// {{ .Doc }}
+		func {{ .Name }}(t {{ .Qual }}Error, {{ .Args }}) bool {
+			if msg, ok := {{ .Check }}; !ok {
+				t.Helper()
+				msg = {{ .Qual }}ExprMsg(msg, {{ .ExprArgs }})
+				msg = {{ .Qual }}Report(t, "{{ .Name }}", msg{{ .GotWant }})
+				t.Error("\n" + msg)
+				return false
+			}
+
+			return true
+		}
+	`),
+	newTemplate(`
+		// {{ .Doc }}
+		func {{ .Name }}f(t {{ .Qual }}Error, {{ .Args }}, format string, args ...any) bool {
+			if msg, ok := {{ .Check }}; !ok {
+				t.Helper()
+				msg = fmt.Sprintf(format, args...) + "\n" + {{ .Qual }}ExprMsg(msg, {{ .ExprArgs }})
+				msg = {{ .Qual }}Report(t, "{{ .Name }}f", msg{{ .GotWant }})
+				t.Error(msg)
+				return false
+			}
+
+			return true
+		}
+	`),
+	newTemplate(`
+		// {{ .Doc }}
+		func Must{{ or .Must .Name }}(t {{ .Qual }}Fatal, {{ .Args }}) {
+			if msg, ok := {{ .Check }}; !ok {
+				t.Helper()
+				msg = {{ .Qual }}ExprMsg(msg, {{ .ExprArgs }})
+				msg = {{ .Qual }}Report(t, "Must{{ or .Must .Name }}", msg{{ .GotWant }})
+				t.Fatal("\n" + msg)
+			}
+		}
+	`),
+	newTemplate(`
+		// {{ .Doc }}
+		func Must{{ or .Must .Name }}f(t {{ .Qual }}Fatal, {{ .Args }}, format string, args ...any) {
+			if msg, ok := {{ .Check }}; !ok {
+				t.Helper()
+				msg = fmt.Sprintf(format, args...) + "\n" + {{ .Qual }}ExprMsg(msg, {{ .ExprArgs }})
+				msg = {{ .Qual }}Report(t, "Must{{ or .Must .Name }}f", msg{{ .GotWant }})
+				t.Fatal(msg)
+			}
+		}
+	`),
+}
@@ -0,0 +1,75 @@
+package checkgen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/thatguystone/cog/check"
+)
+
+func TestParams(t *testing.T) {
+	check.Equal(t, Func{Args: "g, e any"}.Params(), "g, e")
+	check.Equal(t, Func{Args: "err error, target any"}.Params(), "err, target")
+	check.Equal(t, Func{Args: "n int, fn func(i int) bool"}.Params(), "n, fn")
+}
+
+func TestGotWant(t *testing.T) {
+	check.Equal(t, Func{}.GotWant(), "")
+	check.Equal(t, Func{Got: "g", Want: "e"}.GotWant(), ", g, e")
+}
+
+func TestExprArgs(t *testing.T) {
+	data := tmplData{
+		Func: Func{
+			Args:    "a, b any, c int",
+			NoExprs: "a, c",
+		},
+		Qual: "check.",
+	}
+
+	check.Equal(t, data.ExprArgs(), "check.NoExpr, b, check.NoExpr")
+
+	data.NoExprs = ""
+	check.Equal(t, data.ExprArgs(), "a, b, c")
+}
+
+func TestWrite(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("package x\n")
+
+	write(&b, "check.", []Func{
+		{
+			Name:  "Positive",
+			Must:  "BePositive",
+			Args:  "n int",
+			Check: "checkPositive(n)",
+			Doc:   "Check that n is positive.",
+		},
+	})
+
+	f, err := parser.ParseFile(token.NewFileSet(), "x.go", b.String(), parser.ParseComments)
+	check.MustNil(t, err)
+
+	var names []string
+	for _, decl := range f.Decls {
+		fn := decl.(*ast.FuncDecl)
+		names = append(names, fn.Name.Name)
+		check.Equal(t, fn.Doc.Text(), "Check that n is positive.\n")
+	}
+
+	check.Equal(t, names, []string{
+		"Positive",
+		"Positivef",
+		"MustBePositive",
+		"MustBePositivef",
+	})
+
+	src := b.String()
+	check.Contains(t, src, "func Positive(t check.Error, n int) bool {")
+	check.Contains(t, src, "func MustBePositive(t check.Fatal, n int) {")
+	check.Contains(t, src, "msg = check.ExprMsg(msg, n)")
+	check.Contains(t, src, `msg = check.Report(t, "MustBePositivef", msg)`)
+}
@@ -13,7 +13,8 @@ import (
 
 type Buffer struct {
 	bytes.Buffer
-	dstPath string
+	dstPath    string
+	importPath string
 }
 
 func New() *Buffer {
@@ -33,18 +34,26 @@ func New() *Buffer {
 		)
 	}
 
+	name, importPath := getPkg()
+
 	b := &Buffer{
-		dstPath: strings.TrimSuffix(srcPath, cmdSuffix) + outSuffix,
+		dstPath:    strings.TrimSuffix(srcPath, cmdSuffix) + outSuffix,
+		importPath: importPath,
 	}
 
 	fmt.Fprintf(b, "// Code generated by `go generate %s`. DO NOT EDIT.\n", srcPath)
 	fmt.Fprintf(b, "\n")
-	fmt.Fprintf(b, "package %s\n", getPkgName())
+	fmt.Fprintf(b, "package %s\n", name)
 	fmt.Fprintf(b, "\n")
 
 	return b
 }
 
+// ImportPath gets the import path of the package being generated
+func (b *Buffer) ImportPath() string {
+	return b.importPath
+}
+
 func (b *Buffer) WriteFile() {
 	out, err := imports.Process(b.dstPath, b.Bytes(), nil)
 	assert.Nil(err)
@@ -53,15 +62,16 @@ func (b *Buffer) WriteFile() {
 	assert.Nil(err)
 }
 
-func getPkgName() string {
+func getPkg() (name, importPath string) {
 	buf := new(bytes.Buffer)
 
-	cmd := exec.Command("go", "list", "-f={{ .Name }}")
+	cmd := exec.Command("go", "list", "-f={{ .Name }} {{ .ImportPath }}")
 	cmd.Stdout = buf
 	cmd.Stderr = os.Stderr
 
 	err := cmd.Run()
 	assert.Nil(err)
 
-	return strings.TrimSpace(buf.String())
+	name, importPath, _ = strings.Cut(strings.TrimSpace(buf.String()), " ")
+	return
 }